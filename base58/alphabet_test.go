@@ -0,0 +1,46 @@
+package base58
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestAlphabet(t *testing.T) {
+
+	for _, a := range []*Alphabet{RippleAlphabet, FlickrAlphabet} {
+		for i := 0; i < 100; i++ {
+			data := make([]byte, (rand.Int()&63)+1)
+			rand.Read(data)
+			data[0] = 0 // leading zero
+			x, err := a.Decode(a.Encode(data))
+			if err != nil || !bytes.Equal(x, data) {
+				fmt.Printf("%x (expected) %x (actual)\n", data, x)
+				t.Error("FAIL")
+			}
+		}
+	}
+
+	// the ripple zero symbol is 'r'
+	if RippleAlphabet.Encode([]byte{0, 0, 1}) != "rrp" {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestNewAlphabet(t *testing.T) {
+
+	// duplicate character
+	_, err := NewAlphabet("113456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+	// too short
+	_, err = NewAlphabet("123456789")
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
@@ -2,38 +2,76 @@ package base58
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 )
 
-const chars = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
-const nChars = len(chars)
+const nChars = 58
 
-var revChars [128]int8
+// Inputs of at least this many bytes (after any leading zeroes) are
+// converted with math/big. Below this the long division loop is faster.
+const bigThreshold = 8
 
-func init() {
-	for i := range revChars {
-		revChars[i] = -1
+// Alphabet is a base58 symbol set.
+type Alphabet struct {
+	chars    [nChars]byte
+	revChars [256]int8 // maps every byte value to a symbol index, or -1 if invalid
+}
+
+// NewAlphabet returns an alphabet for a string of 58 unique ASCII characters.
+func NewAlphabet(chars string) (*Alphabet, error) {
+	if len(chars) != nChars {
+		return nil, errors.New("alphabet must have 58 characters")
+	}
+	a := &Alphabet{}
+	for i := range a.revChars {
+		a.revChars[i] = -1
 	}
-	for i, c := range chars {
-		revChars[c] = int8(i)
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		if c >= 128 {
+			return nil, fmt.Errorf("non-ASCII alphabet character %q", c)
+		}
+		if a.revChars[c] >= 0 {
+			return nil, fmt.Errorf("duplicate alphabet character %q", c)
+		}
+		a.chars[i] = c
+		a.revChars[c] = int8(i)
 	}
+	return a, nil
 }
 
+func mustAlphabet(chars string) *Alphabet {
+	a, err := NewAlphabet(chars)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+var BitcoinAlphabet = mustAlphabet("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+var RippleAlphabet = mustAlphabet("rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz")
+var FlickrAlphabet = mustAlphabet("123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ")
+
+// Encode returns the base58 encoding of data using the Bitcoin alphabet.
 func Encode(data []byte) string {
+	return BitcoinAlphabet.Encode(data)
+}
 
-	// count the leading zero bytes
-	zeroes := 0
-	for ; zeroes < len(data); zeroes++ {
-		if data[zeroes] != 0 {
-			break
-		}
-	}
+// Decode returns the data for a base58 string using the Bitcoin alphabet.
+func Decode(s string) ([]byte, error) {
+	return BitcoinAlphabet.Decode(s)
+}
+
+// symbolsLoop converts data to base 58 symbol values using long division.
+func symbolsLoop(data []byte) []byte {
 
 	// how many non-zero base 58 symbols do we need?
 	// log(256)/log(58) = 1.365..
-	buf := make([]byte, (((len(data)-zeroes)*137)/100)+1)
+	buf := make([]byte, ((len(data)*137)/100)+1)
 	high := len(buf) - 1
 
-	for i := zeroes; i < len(data); i++ {
+	for i := 0; i < len(data); i++ {
 		carry := int(data[i])
 		var j int
 		for j = len(buf) - 1; (j > high) || (carry != 0); j-- {
@@ -56,26 +94,126 @@ func Encode(data []byte) string {
 	}
 	buf = buf[i:]
 
+	return buf
+}
+
+// radix is the largest power of 58 that fits in a uint64 (58^10).
+const radixDigits = 10
+
+var radix = new(big.Int).SetUint64(430804206899405824)
+
+// symbolsBig converts data to base 58 symbol values using math/big.
+func symbolsBig(data []byte) []byte {
+
+	n := new(big.Int).SetBytes(data)
+	rem := new(big.Int)
+
+	// least significant symbols first
+	buf := make([]byte, 0, ((len(data)*137)/100)+radixDigits)
+	for n.Sign() > 0 {
+		n.QuoRem(n, radix, rem)
+		r := rem.Uint64()
+		for j := 0; j < radixDigits; j++ {
+			buf = append(buf, byte(r%nChars))
+			r /= nChars
+		}
+	}
+
+	// remove the zero-valued symbol bytes
+	for len(buf) > 0 && buf[len(buf)-1] == 0 {
+		buf = buf[:len(buf)-1]
+	}
+
+	// most significant symbols first
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+
+	return buf
+}
+
+// Encode returns the base58 encoding of data.
+// Each leading zero byte is encoded as a zero symbol, so all-zero input
+// gives that many zero symbols. Empty input gives an empty string.
+func (a *Alphabet) Encode(data []byte) string {
+
+	// count the leading zero bytes
+	zeroes := 0
+	for ; zeroes < len(data); zeroes++ {
+		if data[zeroes] != 0 {
+			break
+		}
+	}
+
+	// convert to base 58 symbol values
+	var buf []byte
+	if len(data)-zeroes >= bigThreshold {
+		buf = symbolsBig(data[zeroes:])
+	} else {
+		buf = symbolsLoop(data[zeroes:])
+	}
+
 	// build the encoded buffer
 	encode := make([]byte, zeroes+len(buf))
-	// add '1's for leading zero bytes
+	// add zero symbols for leading zero bytes
 	for i := 0; i < zeroes; i++ {
-		encode[i] = '1'
+		encode[i] = a.chars[0]
 	}
 	// add the encoded symbols
 	for i := zeroes; i < len(encode); i++ {
-		encode[i] = chars[buf[i-zeroes]]
+		encode[i] = a.chars[buf[i-zeroes]]
 	}
 
 	return string(encode)
 }
 
-func Decode(s string) ([]byte, error) {
+// Decode returns the data for a base58 string.
+func (a *Alphabet) Decode(s string) ([]byte, error) {
 
 	if len(s) == 0 {
 		return nil, errors.New("no input")
 	}
 
-	return nil, nil
+	// count the leading zero symbols
+	zeroes := 0
+	for ; zeroes < len(s); zeroes++ {
+		if s[zeroes] != a.chars[0] {
+			break
+		}
+	}
+
+	// how many bytes do we need?
+	// log(58)/log(256) = 0.732..
+	buf := make([]byte, (((len(s)-zeroes)*733)/1000)+1)
+	high := len(buf) - 1
+
+	for i := zeroes; i < len(s); i++ {
+		c := s[i]
+		if a.revChars[c] < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		carry := int(a.revChars[c])
+		var j int
+		for j = len(buf) - 1; (j >= 0) && ((j > high) || (carry != 0)); j-- {
+			carry += int(buf[j]) * nChars
+			buf[j] = byte(carry & 0xff)
+			carry >>= 8
+		}
+		high = j
+	}
+
+	// remove the leading zero bytes
+	i := 0
+	for ; i < len(buf); i++ {
+		if buf[i] != 0 {
+			break
+		}
+	}
+	buf = buf[i:]
+
+	// add 0x00 bytes for the leading zero symbols
+	decode := make([]byte, zeroes+len(buf))
+	copy(decode[zeroes:], buf)
 
+	return decode, nil
 }
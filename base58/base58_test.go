@@ -1,8 +1,11 @@
 package base58
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -75,3 +78,143 @@ func TestEncode(t *testing.T) {
 	}
 
 }
+
+func TestDecode(t *testing.T) {
+
+	for _, test := range hexTests {
+		if test.out == "" {
+			continue
+		}
+		x, err := Decode(test.out)
+		if err != nil {
+			fmt.Printf("%s: %s\n", test.out, err)
+			t.Error("FAIL")
+			continue
+		}
+		if hex.EncodeToString(x) != test.in {
+			fmt.Printf("%s (expected) %x (actual)\n", test.in, x)
+			t.Error("FAIL")
+		}
+	}
+
+	_, err := Decode("")
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+
+		n := (rand.Int() & 255) + 1
+		data := make([]byte, n)
+		rand.Read(data)
+
+		// add some leading zeroes
+		z := rand.Int() & 7
+		if z > n {
+			z = n
+		}
+		for j := 0; j < z; j++ {
+			data[j] = 0
+		}
+
+		x, err := Decode(Encode(data))
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			t.Error("FAIL")
+			continue
+		}
+		if !bytes.Equal(x, data) {
+			fmt.Printf("%x (expected) %x (actual)\n", data, x)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestDecodeInvalid(t *testing.T) {
+
+	// excluded ASCII characters
+	for _, c := range "0OIl" {
+		s := "2NEpo7" + string(c) + "TZRRrLZ"
+		_, err := Decode(s)
+		if err == nil {
+			t.Error("FAIL")
+			continue
+		}
+		expected := fmt.Sprintf("invalid base58 character %q", byte(c))
+		if err.Error() != expected {
+			fmt.Printf("%s (expected) %s (actual)\n", expected, err)
+			t.Error("FAIL")
+		}
+	}
+
+	// non-ASCII
+	for _, s := range []string{"2NEpoé7TZ", "\xff", "1\x80"} {
+		_, err := Decode(s)
+		if err == nil {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestEncodeEmpty(t *testing.T) {
+
+	if Encode(nil) != "" || Encode([]byte{}) != "" {
+		t.Error("FAIL")
+	}
+
+	for n := 1; n < 10; n++ {
+		x := Encode(make([]byte, n))
+		if x != strings.Repeat("1", n) {
+			fmt.Printf("%s (actual)\n", x)
+			t.Error("FAIL")
+		}
+	}
+
+	// base58check of an empty payload
+	x := CheckEncode(0, nil)
+	if x != "1Wh4bh" {
+		fmt.Printf("1Wh4bh (expected) %s (actual)\n", x)
+		t.Error("FAIL")
+	}
+	v, payload, err := CheckDecode(x)
+	if err != nil || v != 0 || len(payload) != 0 {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestSymbolsBig(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+		data := make([]byte, rand.Int()&1023)
+		rand.Read(data)
+		x := symbolsLoop(data)
+		y := symbolsBig(data)
+		if !bytes.Equal(x, y) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func benchmarkSymbols(b *testing.B, n int, fn func([]byte) []byte) {
+	data := make([]byte, n)
+	rand.Read(data)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn(data)
+	}
+}
+
+func BenchmarkLoop32(b *testing.B)  { benchmarkSymbols(b, 32, symbolsLoop) }
+func BenchmarkBig32(b *testing.B)   { benchmarkSymbols(b, 32, symbolsBig) }
+func BenchmarkLoop128(b *testing.B) { benchmarkSymbols(b, 128, symbolsLoop) }
+func BenchmarkBig128(b *testing.B)  { benchmarkSymbols(b, 128, symbolsBig) }
+func BenchmarkLoop1K(b *testing.B)  { benchmarkSymbols(b, 1024, symbolsLoop) }
+func BenchmarkBig1K(b *testing.B)   { benchmarkSymbols(b, 1024, symbolsBig) }
@@ -0,0 +1,57 @@
+//-----------------------------------------------------------------------------
+/*
+
+Base58Check Encoding
+
+https://en.bitcoin.it/wiki/Base58Check_encoding
+
+*/
+//-----------------------------------------------------------------------------
+
+package base58
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+//-----------------------------------------------------------------------------
+
+// checksum returns the first 4 bytes of the double SHA256 of the data.
+func checksum(data []byte) [4]byte {
+	var out [4]byte
+	hash := sha2.Sha2_256d(data)
+	copy(out[:], hash[:4])
+	return out
+}
+
+// CheckEncode returns the base58check encoding of a version byte and payload.
+func CheckEncode(version byte, payload []byte) string {
+	buf := make([]byte, 1+len(payload), 1+len(payload)+4)
+	buf[0] = version
+	copy(buf[1:], payload)
+	cs := checksum(buf)
+	return Encode(append(buf, cs[:]...))
+}
+
+// CheckDecode decodes a base58check string into a version byte and payload.
+func CheckDecode(s string) (byte, []byte, error) {
+	buf, err := Decode(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(buf) < 5 {
+		return 0, nil, errors.New("input too short")
+	}
+	n := len(buf) - 4
+	cs := checksum(buf[:n:n])
+	// constant time, so the comparison doesn't leak the checksum
+	if subtle.ConstantTimeCompare(cs[:], buf[n:]) != 1 {
+		return 0, nil, errors.New("bad checksum")
+	}
+	return buf[0], buf[1:n], nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,86 @@
+package base58
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+var checkTests = []struct {
+	version byte
+	payload string
+	out     string
+}{
+	{0x00, "62e907b15cbf27d5425399ebf6f0fb50ebb88f18", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+	{0x80, "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d", "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"},
+}
+
+func TestCheckEncode(t *testing.T) {
+
+	for _, test := range checkTests {
+		payload, _ := hex.DecodeString(test.payload)
+		x := CheckEncode(test.version, payload)
+		if x != test.out {
+			fmt.Printf("%s (expected) %s (actual)\n", test.out, x)
+			t.Error("FAIL")
+		}
+		version, y, err := CheckDecode(test.out)
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			t.Error("FAIL")
+			continue
+		}
+		if version != test.version || !bytes.Equal(y, payload) {
+			fmt.Printf("%02x %x (expected) %02x %x (actual)\n", test.version, payload, version, y)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestCheckRoundTrip(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+
+		n := rand.Int() & 63
+		payload := make([]byte, n)
+		rand.Read(payload)
+		version := byte(rand.Int())
+
+		v, x, err := CheckDecode(CheckEncode(version, payload))
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			t.Error("FAIL")
+			continue
+		}
+		if v != version || !bytes.Equal(x, payload) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestCheckBadChecksum(t *testing.T) {
+
+	// last character changed
+	_, _, err := CheckDecode("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb")
+	if err == nil || err.Error() != "bad checksum" {
+		t.Error("FAIL")
+	}
+
+	// each checksum byte corrupted in turn
+	for _, test := range checkTests {
+		buf, _ := Decode(test.out)
+		for i := len(buf) - 4; i < len(buf); i++ {
+			x := append([]byte{}, buf...)
+			x[i] ^= 0x01
+			_, _, err := CheckDecode(Encode(x))
+			if err == nil || err.Error() != "bad checksum" {
+				t.Error("FAIL")
+			}
+		}
+	}
+
+}
@@ -0,0 +1,37 @@
+package base58
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestTestVectors(t *testing.T) {
+
+	for _, v := range TestVectors() {
+
+		data, err := hex.DecodeString(v.Hex)
+		if err != nil {
+			t.Error("FAIL")
+			continue
+		}
+
+		s := Encode(data)
+		if s != v.Encoded {
+			fmt.Printf("%s (expected) %s (actual)\n", v.Encoded, s)
+			t.Error("FAIL")
+		}
+
+		// Decode rejects empty input
+		if v.Encoded == "" {
+			continue
+		}
+		x, err := Decode(v.Encoded)
+		if err != nil || !bytes.Equal(x, data) {
+			fmt.Printf("%s (expected) %x (actual)\n", v.Hex, x)
+			t.Error("FAIL")
+		}
+	}
+
+}
@@ -0,0 +1,192 @@
+//-----------------------------------------------------------------------------
+/*
+
+Bech32 Encoding
+
+https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
+
+*/
+//-----------------------------------------------------------------------------
+
+package bech32
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+const chars = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// maximum length of an encoded string
+const maxLength = 90
+
+// length of the checksum in 5 bit values
+const checksumLength = 6
+
+var revChars [256]int8
+
+func init() {
+	for i := range revChars {
+		revChars[i] = -1
+	}
+	for i, c := range chars {
+		revChars[c] = int8(i)
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+func polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// hrpExpand returns the human readable part expanded for checksumming.
+func hrpExpand(hrp string) []byte {
+	out := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+func verifyChecksum(hrp string, data []byte) bool {
+	return polymod(append(hrpExpand(hrp), data...)) == 1
+}
+
+func createChecksum(hrp string, data []byte) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, make([]byte, checksumLength)...)
+	mod := polymod(values) ^ 1
+	out := make([]byte, checksumLength)
+	for i := range out {
+		out[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return out
+}
+
+// checkHrp checks the human readable part characters.
+func checkHrp(hrp string) error {
+	if len(hrp) == 0 {
+		return errors.New("empty human readable part")
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return fmt.Errorf("invalid human readable part character %q", hrp[i])
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// Encode returns the bech32 encoding of a human readable part and 5 bit data values.
+func Encode(hrp string, data []byte) (string, error) {
+	if err := checkHrp(hrp); err != nil {
+		return "", err
+	}
+	if strings.ToLower(hrp) != hrp && strings.ToUpper(hrp) != hrp {
+		return "", errors.New("mixed case human readable part")
+	}
+	hrp = strings.ToLower(hrp)
+	if len(hrp)+1+len(data)+checksumLength > maxLength {
+		return "", errors.New("encoding is too long")
+	}
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range data {
+		if v > 31 {
+			return "", fmt.Errorf("invalid data value %d", v)
+		}
+		sb.WriteByte(chars[v])
+	}
+	for _, v := range createChecksum(hrp, data) {
+		sb.WriteByte(chars[v])
+	}
+	return sb.String(), nil
+}
+
+// Decode returns the human readable part and 5 bit data values of a bech32 string.
+func Decode(s string) (string, []byte, error) {
+	if len(s) > maxLength {
+		return "", nil, errors.New("input is too long")
+	}
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, errors.New("mixed case input")
+	}
+	s = strings.ToLower(s)
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 0 {
+		return "", nil, errors.New("no separator")
+	}
+	hrp := s[:pos]
+	if err := checkHrp(hrp); err != nil {
+		return "", nil, err
+	}
+	if len(s)-pos-1 < checksumLength {
+		return "", nil, errors.New("checksum is too short")
+	}
+	data := make([]byte, len(s)-pos-1)
+	for i := range data {
+		c := s[pos+1+i]
+		if revChars[c] < 0 {
+			return "", nil, fmt.Errorf("invalid bech32 character %q", c)
+		}
+		data[i] = byte(revChars[c])
+	}
+	if !verifyChecksum(hrp, data) {
+		return "", nil, errors.New("bad checksum")
+	}
+	return hrp, data[:len(data)-checksumLength], nil
+}
+
+//-----------------------------------------------------------------------------
+
+// ConvertBits regroups a slice of fromBits values into toBits values.
+// If pad is true a final partial group is zero padded, otherwise
+// non-zero padding bits are an error.
+func ConvertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	nbits := uint(0)
+	maxv := uint32(1)<<toBits - 1
+	out := make([]byte, 0, (uint(len(data))*fromBits+toBits-1)/toBits)
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data value %d", v)
+		}
+		acc = acc<<fromBits | uint32(v)
+		nbits += fromBits
+		for nbits >= toBits {
+			nbits -= toBits
+			out = append(out, byte((acc>>nbits)&maxv))
+		}
+	}
+	if pad {
+		if nbits > 0 {
+			out = append(out, byte((acc<<(toBits-nbits))&maxv))
+		}
+	} else if nbits >= fromBits || (acc<<(toBits-nbits))&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return out, nil
+}
+
+//-----------------------------------------------------------------------------
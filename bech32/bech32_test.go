@@ -0,0 +1,80 @@
+package bech32
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// BIP173 valid checksums
+var validTests = []string{
+	"A12UEL5L",
+	"a12uel5l",
+	"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+	"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+	"11qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqc8247j",
+	"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+	"?1ezyfcl",
+}
+
+// BIP173 invalid checksums
+var invalidTests = []string{
+	"\x201nwldj5", // hrp character out of range
+	"\x7f1axkwrx", // hrp character out of range
+	"\x801eym55h", // hrp character out of range
+	"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", // too long
+	"pzry9x0s0muk",  // no separator
+	"1pzry9x0s0muk", // empty hrp
+	"x1b4n0q5v",     // invalid data character
+	"li1dgmt3",      // checksum too short
+	"de1lg7wt\xff",  // invalid checksum character
+	"A1G7SGD8",      // checksum calculated with uppercase hrp
+	"10a06t8",       // empty hrp
+	"1qzzfhee",      // empty hrp
+	"a12UEL5L",      // mixed case
+}
+
+func TestValid(t *testing.T) {
+
+	for _, test := range validTests {
+		hrp, data, err := Decode(test)
+		if err != nil {
+			fmt.Printf("%s: %s\n", test, err)
+			t.Error("FAIL")
+			continue
+		}
+		s, err := Encode(hrp, data)
+		if err != nil || s != strings.ToLower(test) {
+			fmt.Printf("%s (expected) %s (actual)\n", strings.ToLower(test), s)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestInvalid(t *testing.T) {
+
+	for _, test := range invalidTests {
+		_, _, err := Decode(test)
+		if err == nil {
+			fmt.Printf("%q should not decode\n", test)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestConvertBits(t *testing.T) {
+
+	data := []byte{0x75, 0x1e, 0x76, 0xe8, 0x19, 0x91, 0x96, 0xd4}
+
+	x, err := ConvertBits(data, 8, 5, true)
+	if err != nil {
+		t.Error("FAIL")
+	}
+	y, err := ConvertBits(x, 5, 8, false)
+	if err != nil || string(y) != string(data) {
+		t.Error("FAIL")
+	}
+
+}
@@ -0,0 +1,90 @@
+//-----------------------------------------------------------------------------
+/*
+
+Block File Reader
+
+Bitcoin Core stores blocks in blk*.dat files as a sequence of records:
+magic(4) || size(4) || block(size)
+
+https://en.bitcoin.it/wiki/Data_directory
+
+*/
+//-----------------------------------------------------------------------------
+
+package blkfile
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/deadsy/bcx/block"
+)
+
+//-----------------------------------------------------------------------------
+
+// isZero returns true if the record header is zero padding.
+func isZero(b []byte) bool {
+	for _, x := range b {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Scan reads the block records from r and calls fn with each block header.
+// The rest of each block is skipped. It returns nil at the end of the file,
+// or the first error from fn.
+// The files are preallocated, so the data ends at the end of the file or at
+// the first all-zero record header.
+func Scan(r io.Reader, fn func(*block.Hdr) error) error {
+	return ScanNetwork(r, block.Mainnet, fn)
+}
+
+// ScanNetwork is Scan for the block files of a network.
+func ScanNetwork(r io.Reader, net *block.Network, fn func(*block.Hdr) error) error {
+	magic := net.Magic()
+	var buf [block.HdrSize]byte
+	for n := 0; ; n++ {
+		// record header
+		k, err := io.ReadFull(r, buf[:8])
+		if err == io.EOF || (k > 0 && isZero(buf[:k])) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("record %d: %s", n, err)
+		}
+		if binary.LittleEndian.Uint32(buf[0:4]) != magic {
+			return fmt.Errorf("record %d: bad magic", n)
+		}
+		size := binary.LittleEndian.Uint32(buf[4:8])
+		if size < block.HdrSize {
+			return fmt.Errorf("record %d: block is too short", n)
+		}
+		// block header
+		_, err = io.ReadFull(r, buf[:])
+		if err != nil {
+			return fmt.Errorf("record %d: %s", n, err)
+		}
+		h, err := block.FromBytes(buf[:])
+		if err != nil {
+			return err
+		}
+		// skip the block body
+		skip, err := io.CopyN(io.Discard, r, int64(size-block.HdrSize))
+		if skip != int64(size-block.HdrSize) {
+			if err == nil || err == io.EOF {
+				err = errors.New("truncated block")
+			}
+			return fmt.Errorf("record %d: %s", n, err)
+		}
+		err = fn(h)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,110 @@
+package blkfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/deadsy/bcx/block"
+)
+
+// record returns a block file record for a header and a dummy body.
+func record(h *block.Hdr, bodySize int) []byte {
+	return recordNetwork(block.Mainnet, h, bodySize)
+}
+
+func recordNetwork(net *block.Network, h *block.Hdr, bodySize int) []byte {
+	var buf bytes.Buffer
+	var x [8]byte
+	binary.LittleEndian.PutUint32(x[0:4], net.Magic())
+	binary.LittleEndian.PutUint32(x[4:8], uint32(block.HdrSize+bodySize))
+	buf.Write(x[:])
+	buf.Write(h.Bytes())
+	buf.Write(make([]byte, bodySize))
+	return buf.Bytes()
+}
+
+func TestScan(t *testing.T) {
+
+	hdrs := []*block.Hdr{block.Mainnet.Genesis(), block.Testnet.Genesis()}
+	var file []byte
+	file = append(file, record(hdrs[0], 205)...)
+	file = append(file, record(hdrs[1], 0)...)
+
+	var got []*block.Hdr
+	err := Scan(bytes.NewReader(file), func(h *block.Hdr) error {
+		got = append(got, h)
+		return nil
+	})
+	if err != nil || len(got) != len(hdrs) {
+		t.Error("FAIL")
+		return
+	}
+	for i := range hdrs {
+		if !got[i].Equal(hdrs[i]) {
+			t.Error("FAIL")
+		}
+	}
+
+	// empty file
+	if Scan(bytes.NewReader(nil), func(h *block.Hdr) error { return nil }) != nil {
+		t.Error("FAIL")
+	}
+
+	// truncated body
+	if Scan(bytes.NewReader(file[:len(file)-1]), func(h *block.Hdr) error { return nil }) == nil {
+		t.Error("FAIL")
+	}
+
+	// zero padding after the last record
+	for _, pad := range []int{8, 3, 4096} {
+		n := 0
+		padded := append(append([]byte{}, file...), make([]byte, pad)...)
+		err = Scan(bytes.NewReader(padded), func(h *block.Hdr) error {
+			n++
+			return nil
+		})
+		if err != nil || n != len(hdrs) {
+			t.Error("FAIL")
+		}
+	}
+
+	// bad magic
+	bad := append([]byte{}, file...)
+	bad[0] ^= 1
+	if Scan(bytes.NewReader(bad), func(h *block.Hdr) error { return nil }) == nil {
+		t.Error("FAIL")
+	}
+
+	// stop on a callback error
+	stop := errors.New("stop")
+	n := 0
+	err = Scan(bytes.NewReader(file), func(h *block.Hdr) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestScanNetwork(t *testing.T) {
+
+	file := recordNetwork(block.Testnet, block.Testnet.Genesis(), 10)
+	n := 0
+	err := ScanNetwork(bytes.NewReader(file), block.Testnet, func(h *block.Hdr) error {
+		n++
+		return nil
+	})
+	if err != nil || n != 1 {
+		t.Error("FAIL")
+	}
+
+	// wrong network
+	if Scan(bytes.NewReader(file), func(h *block.Hdr) error { return nil }) == nil {
+		t.Error("FAIL")
+	}
+
+}
@@ -0,0 +1,49 @@
+//-----------------------------------------------------------------------------
+/*
+
+Annotated Header Dump
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//-----------------------------------------------------------------------------
+
+// Annotate returns a labeled breakdown of the serialized header.
+// Each field is shown as hex with its byte offset and a description.
+func Annotate(h *Hdr) string {
+	x := h.Bytes()
+	var sb strings.Builder
+	field := func(ofs, n int, label string) {
+		for i := ofs; i < ofs+n; i += 16 {
+			k := i + 16
+			if k > ofs+n {
+				k = ofs + n
+			}
+			s := hex.EncodeToString(x[i:k])
+			if k == ofs+n {
+				dots := strings.Repeat(".", 35-len(s))
+				fmt.Fprintf(&sb, "%02d %s %s %s\n", i, s, dots, label)
+			} else {
+				fmt.Fprintf(&sb, "%02d %s\n", i, s)
+			}
+		}
+	}
+	field(0, 4, fmt.Sprintf("Block version: %d", h.Version))
+	field(4, 32, fmt.Sprintf("Hash of previous block's header: %s", h.Prev.String()))
+	field(36, 32, fmt.Sprintf("Merkle root: %s", h.Merkle.String()))
+	field(68, 4, fmt.Sprintf("Unix time: %d (%s)", h.Time, h.Timestamp().Format(time.RFC3339)))
+	field(72, 4, fmt.Sprintf("Target: 0x%08x (difficulty %g)", h.Target, Difficulty(h.Target)))
+	field(76, 4, fmt.Sprintf("Nonce: %d", h.Nonce))
+	return sb.String()
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,22 @@
+package block
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestAnnotate(t *testing.T) {
+
+	golden, err := os.ReadFile("testdata/annotate125552.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := Annotate(block125552())
+	if s != string(golden) {
+		fmt.Printf("%s(expected)\n%s(actual)\n", golden, s)
+		t.Error("FAIL")
+	}
+
+}
@@ -19,10 +19,17 @@ package block
 
 import (
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"time"
 
 	"github.com/deadsy/bcx/sha2"
+	"github.com/deadsy/bcx/util"
 )
 
+// HdrSize is the size of a serialized header.
+const HdrSize = 4 + 32 + 32 + 4 + 4 + 4
+
 type Hdr struct {
 	Version uint32       // block version
 	Prev    sha2.Hash256 // hash of previous block's header
@@ -44,8 +51,44 @@ func New(prev, merkle *sha2.Hash256, version, time, target, nonce uint32) *Hdr {
 	}
 }
 
+// MaxFutureTime is how far a header time may be ahead of the current time.
+const MaxFutureTime = 2 * time.Hour
+
+// NewChecked returns a new header after checking the field values.
+// The target must be positive and the time must not be more than
+// MaxFutureTime ahead of the current time.
+func NewChecked(prev, merkle *sha2.Hash256, version, tm, target, nonce uint32) (*Hdr, error) {
+	h := New(prev, merkle, version, tm, target, nonce)
+	if BitsToTarget(target).Sign() <= 0 {
+		return nil, errors.New("target is not positive")
+	}
+	if h.Timestamp().After(time.Now().Add(MaxFutureTime)) {
+		return nil, errors.New("time is too far in the future")
+	}
+	return h, nil
+}
+
+// MinTime is the earliest valid header time (the mainnet genesis block time).
+const MinTime = 1231006505
+
+// Validate checks a header and returns the first failure.
+// The target must be positive, the time must not be before MinTime and the
+// header hash must meet the target.
+func (h *Hdr) Validate() error {
+	if BitsToTarget(h.Target).Sign() <= 0 {
+		return errors.New("target is not positive")
+	}
+	if h.Time < MinTime {
+		return errors.New("time is before the genesis block")
+	}
+	if !h.CheckProofOfWork() {
+		return errors.New("bad proof of work")
+	}
+	return nil
+}
+
 func (h *Hdr) Bytes() []byte {
-	var x [4 + 32 + 32 + 4 + 4 + 4]byte
+	var x [HdrSize]byte
 	binary.LittleEndian.PutUint32(x[0:0+4], h.Version)
 	h.Prev.Copy(x[4 : 4+32])
 	h.Merkle.Copy(x[36 : 36+32])
@@ -54,3 +97,69 @@ func (h *Hdr) Bytes() []byte {
 	binary.LittleEndian.PutUint32(x[76:76+4], h.Nonce)
 	return x[:]
 }
+
+// FromBytes parses a serialized header.
+func FromBytes(data []byte) (*Hdr, error) {
+	if len(data) != HdrSize {
+		return nil, errors.New("header is not 80 bytes")
+	}
+	h := &Hdr{}
+	h.Version = binary.LittleEndian.Uint32(data[0 : 0+4])
+	util.Conv8to32(h.Prev[:], data[4:4+32])
+	util.Conv8to32(h.Merkle[:], data[36:36+32])
+	h.Time = binary.LittleEndian.Uint32(data[68 : 68+4])
+	h.Target = binary.LittleEndian.Uint32(data[72 : 72+4])
+	h.Nonce = binary.LittleEndian.Uint32(data[76 : 76+4])
+	return h, nil
+}
+
+// Hex returns the serialized header as a hex string.
+func (h *Hdr) Hex() string {
+	return hex.EncodeToString(h.Bytes())
+}
+
+// FromHex parses a hex string of a serialized header.
+func FromHex(s string) (*Hdr, error) {
+	x, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return FromBytes(x)
+}
+
+// Timestamp returns the header time.
+func (h *Hdr) Timestamp() time.Time {
+	return time.Unix(int64(h.Time), 0).UTC()
+}
+
+// SetTimestamp sets the header time.
+// The time is an unsigned 32 bit unix time, so it overflows in 2106.
+func (h *Hdr) SetTimestamp(t time.Time) {
+	h.Time = uint32(t.Unix())
+}
+
+// NextNonce increments the header nonce.
+// It returns false if the nonce wrapped from 0xffffffff to 0, meaning the
+// nonce space has been exhausted for this header.
+func (h *Hdr) NextNonce() bool {
+	h.Nonce++
+	return h.Nonce != 0
+}
+
+// Hash returns the double SHA256 hash of the serialized header.
+func (h *Hdr) Hash() sha2.Hash256 {
+	var out sha2.Hash256
+	x := sha2.Sha2_256d(h.Bytes())
+	util.Conv8to32(out[:], x[:])
+	return out
+}
+
+// Equal returns true if all the header fields are equal.
+func (h *Hdr) Equal(other *Hdr) bool {
+	return h.Version == other.Version &&
+		h.Prev.Equal(&other.Prev) &&
+		h.Merkle.Equal(&other.Merkle) &&
+		h.Time == other.Time &&
+		h.Target == other.Target &&
+		h.Nonce == other.Nonce
+}
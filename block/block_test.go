@@ -0,0 +1,219 @@
+package block
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+// block125552 returns the header for block 125552.
+func block125552() *Hdr {
+	prev, _ := sha2.FromString("81cd02ab7e569e8bcd9317e2fe99f2de44d49ab2b8851ba4a308000000000000")
+	merkle, _ := sha2.FromString("e320b6c2fffc8d750423db8b1eb942ae710e951ed797f7affc8892b0f1fc122b")
+	return New(&prev, &merkle, 1, 1305998791, 440711666, 2504433986)
+}
+
+func TestHash(t *testing.T) {
+
+	h := block125552()
+	hash := h.Hash()
+
+	expected := "00000000000000001e8d6829a8a21adc5d38d0a473b144b6765798e61f98bd1d"
+	if hash.String() != expected {
+		fmt.Printf("%s (expected) %s (actual)\n", expected, hash.String())
+		t.Error("FAIL")
+	}
+
+}
+
+func TestFromBytes(t *testing.T) {
+
+	x := block125552().Bytes()
+
+	h, err := FromBytes(x)
+	if err != nil {
+		t.Error("FAIL")
+		return
+	}
+	y := h.Bytes()
+	if !bytes.Equal(x, y) {
+		fmt.Printf("%x (expected) %x (actual)\n", x, y)
+		t.Error("FAIL")
+	}
+
+	_, err = FromBytes(x[:79])
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestHex(t *testing.T) {
+
+	x := block125552().Hex()
+
+	h, err := FromHex(x)
+	if err != nil {
+		t.Error("FAIL")
+		return
+	}
+	y := h.Hex()
+	if x != y {
+		fmt.Printf("%s (expected) %s (actual)\n", x, y)
+		t.Error("FAIL")
+	}
+
+	_, err = FromHex(x[:158])
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+	_, err = FromHex(x + "00")
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestTimestamp(t *testing.T) {
+
+	h := block125552()
+	ts := time.Date(2011, 5, 21, 17, 26, 31, 0, time.UTC)
+	if !h.Timestamp().Equal(ts) {
+		fmt.Printf("%s (expected) %s (actual)\n", ts, h.Timestamp())
+		t.Error("FAIL")
+	}
+
+	h.SetTimestamp(ts.Add(time.Hour))
+	if h.Time != 1305998791+3600 {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestNewChecked(t *testing.T) {
+
+	h := block125552()
+
+	_, err := NewChecked(&h.Prev, &h.Merkle, h.Version, h.Time, h.Target, h.Nonce)
+	if err != nil {
+		t.Error("FAIL")
+	}
+
+	// zero target
+	_, err = NewChecked(&h.Prev, &h.Merkle, h.Version, h.Time, 0x1d000000, h.Nonce)
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+	// negative target
+	_, err = NewChecked(&h.Prev, &h.Merkle, h.Version, h.Time, 0x1d800001, h.Nonce)
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+	// time in the future
+	future := uint32(time.Now().Add(3 * time.Hour).Unix())
+	_, err = NewChecked(&h.Prev, &h.Merkle, h.Version, future, h.Target, h.Nonce)
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestEqual(t *testing.T) {
+
+	x := block125552()
+	y, _ := FromBytes(x.Bytes())
+	if !x.Equal(y) || !y.Equal(x) {
+		t.Error("FAIL")
+	}
+
+	// differ only in the nonce
+	y.Nonce++
+	if x.Equal(y) {
+		t.Error("FAIL")
+	}
+
+	y = block125552()
+	y.Merkle[7] ^= 1
+	if x.Equal(y) {
+		t.Error("FAIL")
+	}
+
+}
+
+func FuzzHeaderRoundTrip(f *testing.F) {
+
+	f.Add(block125552().Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) != HdrSize {
+			t.Skip()
+		}
+		h, err := FromBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x := h.Bytes()
+		if !bytes.Equal(x, data) {
+			t.Errorf("%x (expected) %x (actual)", data, x)
+		}
+		if h.Hash() != h.Hash() {
+			t.Error("hash is not deterministic")
+		}
+	})
+
+}
+
+func TestNextNonce(t *testing.T) {
+
+	h := block125552()
+	h.Nonce = 0xfffffffe
+	if !h.NextNonce() || h.Nonce != 0xffffffff {
+		t.Error("FAIL")
+	}
+	if h.NextNonce() || h.Nonce != 0 {
+		t.Error("FAIL")
+	}
+	if !h.NextNonce() || h.Nonce != 1 {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestValidate(t *testing.T) {
+
+	for _, net := range []*Network{Mainnet, Testnet, Regtest} {
+		if err := net.Genesis().Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := block125552().Validate(); err != nil {
+		t.Error(err)
+	}
+
+	tests := []struct {
+		modify func(h *Hdr)
+		err    string
+	}{
+		{func(h *Hdr) { h.Target = 0 }, "target is not positive"},
+		{func(h *Hdr) { h.Target = 0x04923456 }, "target is not positive"},
+		{func(h *Hdr) { h.Time = MinTime - 1 }, "time is before the genesis block"},
+		{func(h *Hdr) { h.Nonce++ }, "bad proof of work"},
+	}
+
+	for _, test := range tests {
+		h := Mainnet.Genesis()
+		test.modify(h)
+		err := h.Validate()
+		if err == nil || err.Error() != test.err {
+			fmt.Printf("%s (expected) %v (actual)\n", test.err, err)
+			t.Error("FAIL")
+		}
+	}
+
+}
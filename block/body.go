@@ -0,0 +1,189 @@
+//-----------------------------------------------------------------------------
+/*
+
+Serialized Blocks
+
+https://developer.bitcoin.org/reference/block_chain.html#serialized-blocks
+https://developer.bitcoin.org/reference/transactions.html#raw-transaction-format
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"errors"
+
+	"github.com/deadsy/bcx/sha2"
+	"github.com/deadsy/bcx/util"
+)
+
+//-----------------------------------------------------------------------------
+
+// Block is a header and its raw serialized transactions.
+type Block struct {
+	Hdr *Hdr
+	Txs [][]byte
+}
+
+// ParseBlock parses a serialized block.
+func ParseBlock(data []byte) (*Block, error) {
+	if len(data) < HdrSize {
+		return nil, errors.New("block is too short")
+	}
+	hdr, err := FromBytes(data[:HdrSize])
+	if err != nil {
+		return nil, err
+	}
+	data = data[HdrSize:]
+
+	count, n, err := util.CompactSize(data)
+	if err != nil {
+		return nil, err
+	}
+	data = data[n:]
+
+	// each transaction is at least 10 bytes
+	if count > uint64(len(data)/10) {
+		return nil, errors.New("bad transaction count")
+	}
+
+	b := &Block{
+		Hdr: hdr,
+		Txs: make([][]byte, count),
+	}
+	for i := range b.Txs {
+		n, _, err := txSize(data)
+		if err != nil {
+			return nil, err
+		}
+		b.Txs[i] = data[:n:n]
+		data = data[n:]
+	}
+
+	if len(data) != 0 {
+		return nil, errors.New("trailing bytes after transactions")
+	}
+	return b, nil
+}
+
+// Hash returns the block hash.
+func (b *Block) Hash() sha2.Hash256 {
+	return b.Hdr.Hash()
+}
+
+// Txids returns the txids of the block transactions.
+func (b *Block) Txids() ([]sha2.Hash256, error) {
+	txids := make([]sha2.Hash256, len(b.Txs))
+	for i := range b.Txs {
+		x, err := Txid(b.Txs[i])
+		if err != nil {
+			return nil, err
+		}
+		txids[i] = x
+	}
+	return txids, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// txReader reads the fields of a serialized transaction.
+type txReader struct {
+	buf []byte
+	ofs int
+	err error
+}
+
+func (r *txReader) skip(n uint64) {
+	if r.err != nil {
+		return
+	}
+	if n > uint64(len(r.buf)-r.ofs) {
+		r.err = errors.New("transaction is truncated")
+		return
+	}
+	r.ofs += int(n)
+}
+
+func (r *txReader) compactSize() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n, err := util.CompactSize(r.buf[r.ofs:])
+	if err != nil {
+		r.err = err
+		return 0
+	}
+	r.ofs += n
+	return v
+}
+
+// txSize returns the length of the serialized transaction at the start of data.
+// For a segwit transaction it also returns the offset of the witness data.
+func txSize(data []byte) (int, int, error) {
+	r := &txReader{buf: data}
+	witness := 0
+
+	r.skip(4) // version
+
+	// segwit marker and flag
+	segwit := len(data) >= 6 && data[4] == 0 && data[5] == 1
+	if segwit {
+		r.skip(2)
+	}
+
+	nIn := r.compactSize()
+	for i := uint64(0); i < nIn && r.err == nil; i++ {
+		r.skip(32 + 4) // previous output
+		r.skip(r.compactSize())
+		r.skip(4) // sequence
+	}
+
+	nOut := r.compactSize()
+	for i := uint64(0); i < nOut && r.err == nil; i++ {
+		r.skip(8) // value
+		r.skip(r.compactSize())
+	}
+
+	if segwit {
+		witness = r.ofs
+		for i := uint64(0); i < nIn && r.err == nil; i++ {
+			nItems := r.compactSize()
+			for j := uint64(0); j < nItems && r.err == nil; j++ {
+				r.skip(r.compactSize())
+			}
+		}
+	}
+
+	r.skip(4) // lock time
+
+	if r.err != nil {
+		return 0, 0, r.err
+	}
+	return r.ofs, witness, nil
+}
+
+// Txid returns the txid of a serialized transaction.
+// For segwit transactions the marker, flag and witness data are not hashed.
+func Txid(tx []byte) (sha2.Hash256, error) {
+	var out sha2.Hash256
+	n, witness, err := txSize(tx)
+	if err != nil {
+		return out, err
+	}
+	if n != len(tx) {
+		return out, errors.New("trailing bytes after transaction")
+	}
+	if witness != 0 {
+		x := make([]byte, 0, 4+(witness-6)+4)
+		x = append(x, tx[:4]...)
+		x = append(x, tx[6:witness]...)
+		x = append(x, tx[n-4:]...)
+		tx = x
+	}
+	hash := sha2.Sha2_256d(tx)
+	util.Conv8to32(out[:], hash[:])
+	return out, nil
+}
+
+//-----------------------------------------------------------------------------
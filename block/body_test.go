@@ -0,0 +1,114 @@
+package block
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+// mainnet genesis block
+const genesisBlock = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c0101000000010000000000000000000000000000000000000000000000000000000000000000ffffffff4d04ffff001d0104455468652054696d65732030332f4a616e2f32303039204368616e63656c6c6f72206f6e206272696e6b206f66207365636f6e64206261696c6f757420666f722062616e6b73ffffffff0100f2052a01000000434104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac00000000"
+
+// mainnet block 100000 (4 transactions)
+const block100000 = "" +
+	"0100000050120119172a610421a6c3011dd330d9df07b63616c2cc1f1cd00200000000006657a9252aacd5c0b2940996ecff952228c3067cc38d4885efb5a4ac" +
+	"4247e9f337221b4d4c86041b0f2b57100401000000010000000000000000000000000000000000000000000000000000000000000000ffffffff08044c86041b" +
+	"020602ffffffff0100f2052a010000004341041b0e8c2567c12536aa13357b79a073dc4444acb83c4ec7a0e2f99dd7457516c5817242da796924ca4e99947d08" +
+	"7fedf9ce467cb9f7c6287078f801df276fdf84ac000000000100000001032e38e9c0a84c6046d687d10556dcacc41d275ec55fc00779ac88fdf357a187000000" +
+	"008c493046022100c352d3dd993a981beba4a63ad15c209275ca9470abfcd57da93b58e4eb5dce82022100840792bc1f456062819f15d33ee7055cf7b5ee1af1" +
+	"ebcc6028d9cdb1c3af7748014104f46db5e9d61a9dc27b8d64ad23e7383a4e6ca164593c2527c038c0857eb67ee8e825dca65046b82c9331586c82e0fd1f633f" +
+	"25f87c161bc6f8a630121df2b3d3ffffffff0200e32321000000001976a914c398efa9c392ba6013c5e04ee729755ef7f58b3288ac000fe208010000001976a9" +
+	"14948c765a6914d43f2a7ac177da2c2f6b52de3d7c88ac000000000100000001c33ebff2a709f13d9f9a7569ab16a32786af7d7e2de09265e41c61d078294ecf" +
+	"010000008a4730440220032d30df5ee6f57fa46cddb5eb8d0d9fe8de6b342d27942ae90a3231e0ba333e02203deee8060fdc70230a7f5b4ad7d7bc3e628cbe21" +
+	"9a886b84269eaeb81e26b4fe014104ae31c31bf91278d99b8377a35bbce5b27d9fff15456839e919453fc7b3f721f0ba403ff96c9deeb680e5fd341c0fc3a7b9" +
+	"0da4631ee39560639db462e9cb850fffffffff0240420f00000000001976a914b0dcbf97eabf4404e31d952477ce822dadbe7e1088acc060d211000000001976" +
+	"a9146b1281eec25ab4e1e0793ff4e08ab1abb3409cd988ac0000000001000000010b6072b386d4a773235237f64c1126ac3b240c84b917a3909ba1c43ded5f51" +
+	"f4000000008c493046022100bb1ad26df930a51cce110cf44f7a48c3c561fd977500b1ae5d6b6fd13d0b3f4a022100c5b42951acedff14abba2736fd574bdb46" +
+	"5f3e6f8da12e2c5303954aca7f78f3014104a7135bfe824c97ecc01ec7d7e336185c81e2aa2c41ab175407c09484ce9694b44953fcb751206564a9c24dd094d4" +
+	"2fdbfdd5aad3e063ce6af4cfaaea4ea14fbbffffffff0140420f00000000001976a91439aa3d569e06a1d7926dc4be1193c99bf2eb9ee088ac00000000"
+
+func TestParseBlock(t *testing.T) {
+
+	data, _ := hex.DecodeString(block100000)
+	orig := append([]byte{}, data...)
+	b, err := ParseBlock(data)
+	if err != nil {
+		fmt.Printf("%s\n", err)
+		t.Error("FAIL")
+		return
+	}
+
+	hash := b.Hash()
+	expected := "000000000003ba27aa200b1cecaad478d2b00432346c3f1f3986da1afd33e506"
+	if hash.String() != expected {
+		fmt.Printf("%s (expected) %s (actual)\n", expected, hash.String())
+		t.Error("FAIL")
+	}
+
+	if len(b.Txs) != 4 || len(b.Txs[0]) != 135 {
+		t.Error("FAIL")
+	}
+
+	txids, err := b.Txids()
+	if err != nil {
+		fmt.Printf("%s\n", err)
+		t.Error("FAIL")
+		return
+	}
+	x := hashList(txids100000)
+	for i := range txids {
+		if !txids[i].Equal(&x[i]) {
+			fmt.Printf("%s (expected) %s (actual)\n", x[i].String(), txids[i].String())
+			t.Error("FAIL")
+		}
+	}
+	root := MerkleRoot(txids)
+	if !root.Equal(&b.Hdr.Merkle) {
+		t.Error("FAIL")
+	}
+
+	// hashing must not modify the block data
+	if !bytes.Equal(data, orig) {
+		t.Error("FAIL")
+	}
+
+	// truncated
+	_, err = ParseBlock(data[:len(data)-1])
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+	// trailing bytes
+	_, err = ParseBlock(append(data, 0))
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestSegwitTxid(t *testing.T) {
+
+	data, _ := hex.DecodeString(genesisBlock)
+	tx := data[HdrSize+1:]
+	txid, _ := Txid(tx)
+
+	// add a marker, flag and a witness with one 3 byte item
+	var stx []byte
+	stx = append(stx, tx[:4]...)
+	stx = append(stx, 0, 1)
+	stx = append(stx, tx[4:len(tx)-4]...)
+	stx = append(stx, 1, 3, 0xaa, 0xbb, 0xcc)
+	stx = append(stx, tx[len(tx)-4:]...)
+
+	n, witness, err := txSize(stx)
+	if err != nil || n != len(stx) || witness != len(stx)-9 {
+		t.Error("FAIL")
+	}
+
+	x, err := Txid(stx)
+	if err != nil || !x.Equal(&txid) {
+		t.Error("FAIL")
+	}
+
+}
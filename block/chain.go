@@ -0,0 +1,64 @@
+//-----------------------------------------------------------------------------
+/*
+
+Header Chain Validation
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"fmt"
+	"sort"
+)
+
+//-----------------------------------------------------------------------------
+
+// MedianTimeSpan is the number of previous headers used for median time past.
+const MedianTimeSpan = 11
+
+// MedianTimePast returns the median time of the last MedianTimeSpan headers.
+// If there are fewer headers the median of those is used, and an empty list
+// returns 0.
+func MedianTimePast(headers []*Hdr) uint32 {
+	if len(headers) > MedianTimeSpan {
+		headers = headers[len(headers)-MedianTimeSpan:]
+	}
+	if len(headers) == 0 {
+		return 0
+	}
+	times := make([]uint32, len(headers))
+	for i, h := range headers {
+		times[i] = h.Time
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return times[len(times)/2]
+}
+
+//-----------------------------------------------------------------------------
+
+// ValidateChain checks a list of consecutive headers.
+// Each header must link to the hash of the previous header, satisfy its own
+// proof of work, and have a time after the median time past of the previous
+// headers.
+// The error identifies the index of the first bad header.
+func ValidateChain(headers []*Hdr) error {
+	for i, h := range headers {
+		if i > 0 {
+			prev := headers[i-1].Hash()
+			if !h.Prev.Equal(&prev) {
+				return fmt.Errorf("header %d: previous hash mismatch", i)
+			}
+			if h.Time <= MedianTimePast(headers[:i]) {
+				return fmt.Errorf("header %d: time is not after the median time past", i)
+			}
+		}
+		if !h.CheckProofOfWork() {
+			return fmt.Errorf("header %d: bad proof of work", i)
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
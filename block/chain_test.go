@@ -0,0 +1,103 @@
+package block
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+// testChain returns a chain of mined headers with an easy target.
+func testChain(n int) []*Hdr {
+	headers := make([]*Hdr, n)
+	var prev sha2.Hash256
+	for i := range headers {
+		merkle := sha2.Init256()
+		merkle[0] = uint32(i)
+		h := New(&prev, &merkle, 1, 1600000000+uint32(i)*600, 0x207fffff, 0)
+		Mine(h)
+		headers[i] = h
+		prev = h.Hash()
+	}
+	return headers
+}
+
+// badNonce sets a nonce that fails the proof of work.
+func badNonce(h *Hdr) {
+	for h.CheckProofOfWork() {
+		h.Nonce++
+	}
+}
+
+func TestValidateChain(t *testing.T) {
+
+	headers := testChain(10)
+	if err := ValidateChain(headers); err != nil {
+		t.Error(err)
+	}
+
+	// bad link
+	headers = testChain(10)
+	headers[5].Prev[0] ^= 1
+	if err := ValidateChain(headers); err == nil || err.Error() != "header 5: previous hash mismatch" {
+		t.Error("FAIL")
+	}
+
+	// bad proof of work (on the last header so the links are intact)
+	headers = testChain(10)
+	badNonce(headers[9])
+	if err := ValidateChain(headers); err == nil || err.Error() != "header 9: bad proof of work" {
+		t.Error("FAIL")
+	}
+
+	// time goes backwards
+	headers = testChain(2)
+	headers[1].Time = headers[0].Time - 1
+	Mine(headers[1])
+	if err := ValidateChain(headers); err == nil || err.Error() != "header 1: time is not after the median time past" {
+		t.Error("FAIL")
+	}
+
+	// earlier than the previous header, but after the median time past
+	headers = testChain(4)
+	headers[3].Time = headers[2].Time - 1
+	Mine(headers[3])
+	if err := ValidateChain(headers); err != nil {
+		t.Error(err)
+	}
+
+}
+
+func TestMedianTimePast(t *testing.T) {
+
+	hdrs := func(times ...uint32) []*Hdr {
+		out := make([]*Hdr, len(times))
+		for i := range times {
+			out[i] = &Hdr{Time: times[i]}
+		}
+		return out
+	}
+
+	tests := []struct {
+		headers []*Hdr
+		mtp     uint32
+	}{
+		{hdrs(), 0},
+		{hdrs(100), 100},
+		{hdrs(100, 200), 200},
+		{hdrs(300, 100, 200), 200},
+		{hdrs(10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110), 60},
+		{hdrs(110, 10, 100, 20, 90, 30, 80, 40, 70, 50, 60), 60},
+		// only the last 11 are used
+		{hdrs(1000, 1000, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110), 60},
+	}
+
+	for _, test := range tests {
+		mtp := MedianTimePast(test.headers)
+		if mtp != test.mtp {
+			fmt.Printf("%d (expected) %d (actual)\n", test.mtp, mtp)
+			t.Error("FAIL")
+		}
+	}
+
+}
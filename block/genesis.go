@@ -0,0 +1,40 @@
+//-----------------------------------------------------------------------------
+/*
+
+Genesis Blocks
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"github.com/deadsy/bcx/sha2"
+)
+
+//-----------------------------------------------------------------------------
+
+// genesis merkle root (the coinbase txid), shared by all networks
+const genesisMerkle = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+
+// Genesis returns the genesis block header for the network.
+func (n *Network) Genesis() *Hdr {
+	var prev sha2.Hash256
+	merkle, err := sha2.FromStringReversed(genesisMerkle)
+	if err != nil {
+		panic(err)
+	}
+	return New(&prev, &merkle, 1, n.genesisTime, n.powLimitBits, n.genesisNonce)
+}
+
+// Genesis returns the mainnet or testnet (testnet3) genesis block header.
+//
+// Deprecated: Use Network.Genesis.
+func Genesis(mainnet bool) *Hdr {
+	if mainnet {
+		return Mainnet.Genesis()
+	}
+	return Testnet.Genesis()
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,34 @@
+package block
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGenesis(t *testing.T) {
+
+	for _, test := range []struct {
+		net  *Network
+		hash string
+	}{
+		{Mainnet, "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"},
+		{Testnet, "000000000933ea01ad0ee984209779baaec3ced90fa3f408719526f8d77f4943"},
+		{Regtest, "0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206"},
+	} {
+		h := test.net.Genesis()
+		hash := h.Hash()
+		if hash.String() != test.hash {
+			fmt.Printf("%s (expected) %s (actual)\n", test.hash, hash.String())
+			t.Error("FAIL")
+		}
+		if !h.CheckProofOfWork() || !h.Prev.IsZero() || h.Target != test.net.PowLimitBits() {
+			t.Error("FAIL")
+		}
+	}
+
+	// deprecated
+	if !Genesis(true).Equal(Mainnet.Genesis()) || !Genesis(false).Equal(Testnet.Genesis()) {
+		t.Error("FAIL")
+	}
+
+}
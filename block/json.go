@@ -0,0 +1,70 @@
+//-----------------------------------------------------------------------------
+/*
+
+JSON Marshalling for Block Headers
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+//-----------------------------------------------------------------------------
+
+// jsonHdr is the JSON form of a header.
+// Hashes are hex strings in display order (sha2.Hash256 MarshalText).
+// Timestamp is informational, Time is used if both are present.
+type jsonHdr struct {
+	Version   uint32       `json:"version"`
+	Prev      sha2.Hash256 `json:"prev"`
+	Merkle    sha2.Hash256 `json:"merkle"`
+	Time      uint32       `json:"time"`
+	Timestamp string       `json:"timestamp,omitempty"`
+	Target    uint32       `json:"target"`
+	Nonce     uint32       `json:"nonce"`
+}
+
+func (h *Hdr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonHdr{
+		Version:   h.Version,
+		Prev:      h.Prev,
+		Merkle:    h.Merkle,
+		Time:      h.Time,
+		Timestamp: h.Timestamp().Format(time.RFC3339),
+		Target:    h.Target,
+		Nonce:     h.Nonce,
+	})
+}
+
+func (h *Hdr) UnmarshalJSON(data []byte) error {
+	var x jsonHdr
+	err := json.Unmarshal(data, &x)
+	if err != nil {
+		return err
+	}
+	t := x.Time
+	if t == 0 && x.Timestamp != "" {
+		ts, err := time.Parse(time.RFC3339, x.Timestamp)
+		if err != nil {
+			return err
+		}
+		t = uint32(ts.Unix())
+	}
+	*h = Hdr{
+		Version: x.Version,
+		Prev:    x.Prev,
+		Merkle:  x.Merkle,
+		Time:    t,
+		Target:  x.Target,
+		Nonce:   x.Nonce,
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,38 @@
+package block
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+
+	h := block125552()
+	x, err := json.Marshal(h)
+	if err != nil {
+		t.Error("FAIL")
+	}
+
+	var y Hdr
+	err = json.Unmarshal(x, &y)
+	if err != nil || y != *h {
+		fmt.Printf("%s\n", x)
+		t.Error("FAIL")
+	}
+
+	// time as an RFC3339 string
+	s := `{"version":1,"prev":"00000000000008a3a41b85b8b29ad444def299fee21793cd8b9e567eab02cd81","merkle":"2b12fcf1b09288fcaff797d71e950e71ae42b91e8bdb2304758dfcffc2b620e3","timestamp":"2011-05-21T17:26:31Z","target":440711666,"nonce":2504433986}`
+	err = json.Unmarshal([]byte(s), &y)
+	if err != nil || y != *h {
+		t.Error("FAIL")
+	}
+
+	// short hash
+	s = `{"version":1,"prev":"000000000008a3a41b85b8b29ad444def299fee21793cd8b9e567eab02cd81","merkle":"2b12fcf1b09288fcaff797d71e950e71ae42b91e8bdb2304758dfcffc2b620e3"}`
+	err = json.Unmarshal([]byte(s), &y)
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
@@ -0,0 +1,109 @@
+//-----------------------------------------------------------------------------
+/*
+
+Merkle Trees
+
+https://developer.bitcoin.org/reference/block_chain.html#merkle-trees
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"errors"
+
+	"github.com/deadsy/bcx/sha2"
+	"github.com/deadsy/bcx/util"
+)
+
+//-----------------------------------------------------------------------------
+
+// merkleParent returns the double SHA256 of the concatenated child hashes.
+func merkleParent(a, b *sha2.Hash256) sha2.Hash256 {
+	var buf [2 * sha2.Size256]byte
+	a.Copy(buf[:sha2.Size256])
+	b.Copy(buf[sha2.Size256:])
+	x := sha2.Sha2_256d(buf[:])
+	var out sha2.Hash256
+	util.Conv8to32(out[:], x[:])
+	return out
+}
+
+// MerkleRoot returns the merkle root of a list of txids.
+// If a level has an odd number of hashes the last hash is duplicated.
+// A single txid is the root, it is not hashed again.
+func MerkleRoot(txids []sha2.Hash256) sha2.Hash256 {
+	if len(txids) == 0 {
+		panic("len(txids) == 0")
+	}
+	level := make([]sha2.Hash256, len(txids))
+	copy(level, txids)
+	for len(level) > 1 {
+		if len(level)&1 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		for i := 0; i < len(level)/2; i++ {
+			level[i] = merkleParent(&level[2*i], &level[2*i+1])
+		}
+		level = level[:len(level)/2]
+	}
+	return level[0]
+}
+
+// SetMerkleFromTxids sets the header merkle root for a list of txids.
+// It panics if the list is empty.
+func (h *Hdr) SetMerkleFromTxids(txids []sha2.Hash256) {
+	h.Merkle = MerkleRoot(txids)
+}
+
+// CoinbaseMerkle returns the merkle root for a block with only a coinbase
+// transaction. This is the coinbase txid itself, not a hash of it.
+func CoinbaseMerkle(coinbaseTxid sha2.Hash256) sha2.Hash256 {
+	return MerkleRoot([]sha2.Hash256{coinbaseTxid})
+}
+
+// MerkleProof returns the merkle path for the txid at index.
+// The path is the list of sibling hashes from the leaf up to the root.
+// directions[i] is true if path[i] is the right hand sibling.
+func MerkleProof(txids []sha2.Hash256, index int) ([]sha2.Hash256, []bool, error) {
+	if index < 0 || index >= len(txids) {
+		return nil, nil, errors.New("index out of range")
+	}
+	var path []sha2.Hash256
+	var directions []bool
+	level := make([]sha2.Hash256, len(txids))
+	copy(level, txids)
+	for len(level) > 1 {
+		if len(level)&1 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		right := index&1 == 0
+		path = append(path, level[index^1])
+		directions = append(directions, right)
+		for i := 0; i < len(level)/2; i++ {
+			level[i] = merkleParent(&level[2*i], &level[2*i+1])
+		}
+		level = level[:len(level)/2]
+		index >>= 1
+	}
+	return path, directions, nil
+}
+
+// VerifyMerkleProof returns true if the merkle path leads from the leaf to the root.
+func VerifyMerkleProof(leaf sha2.Hash256, path []sha2.Hash256, directions []bool, root sha2.Hash256) bool {
+	if len(path) != len(directions) {
+		return false
+	}
+	x := leaf
+	for i := range path {
+		if directions[i] {
+			x = merkleParent(&x, &path[i])
+		} else {
+			x = merkleParent(&path[i], &x)
+		}
+	}
+	return x.Equal(&root)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,137 @@
+package block
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+// block 100000 txids (internal byte order)
+var txids100000 = []string{
+	"876dd0a3ef4a2816ffd1c12ab649825a958b0ff3bb3d6f3e1250f13ddbf0148c",
+	"c40297f730dd7b5a99567eb8d27b78758f607507c52292d02d4031895b52f2ff",
+	"c46e239ab7d28e2c019b6d66ad8fae98a56ef1f21aeecb94d1b1718186f05963",
+	"1d0cb83721529a062d9675b98d6e5c587e4a770fc84ed00abc5a5de04568a6e9",
+}
+
+func hashList(s []string) []sha2.Hash256 {
+	out := make([]sha2.Hash256, len(s))
+	for i := range s {
+		out[i], _ = sha2.FromString(s[i])
+	}
+	return out
+}
+
+func TestMerkleRoot(t *testing.T) {
+
+	root := MerkleRoot(hashList(txids100000))
+	expected := "f3e94742aca4b5ef85488dc37c06c3282295ffec960994b2c0d5ac2a25a95766"
+	if root.String() != expected {
+		fmt.Printf("%s (expected) %s (actual)\n", expected, root.String())
+		t.Error("FAIL")
+	}
+
+	// single txid
+	txids := hashList(txids100000[:1])
+	root = MerkleRoot(txids)
+	if !root.Equal(&txids[0]) {
+		t.Error("FAIL")
+	}
+
+	// odd count duplicates the last hash
+	txids = hashList(txids100000[:3])
+	a := merkleParent(&txids[0], &txids[1])
+	b := merkleParent(&txids[2], &txids[2])
+	x := merkleParent(&a, &b)
+	root = MerkleRoot(txids)
+	if !root.Equal(&x) {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestMerkleProof(t *testing.T) {
+
+	for n := 1; n <= 17; n++ {
+
+		txids := make([]sha2.Hash256, n)
+		for i := range txids {
+			for j := range txids[i] {
+				txids[i][j] = rand.Uint32()
+			}
+		}
+		root := MerkleRoot(txids)
+
+		for i := range txids {
+			path, directions, err := MerkleProof(txids, i)
+			if err != nil {
+				t.Error("FAIL")
+				continue
+			}
+			if !VerifyMerkleProof(txids[i], path, directions, root) {
+				fmt.Printf("n %d index %d: bad proof\n", n, i)
+				t.Error("FAIL")
+			}
+			// the proof should not work for another leaf
+			if n > 1 && VerifyMerkleProof(txids[(i+1)%n], path, directions, root) {
+				t.Error("FAIL")
+			}
+		}
+
+		_, _, err := MerkleProof(txids, n)
+		if err == nil {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestCoinbaseMerkle(t *testing.T) {
+
+	txid := hashList(txids100000[:1])[0]
+	root := CoinbaseMerkle(txid)
+	if !root.Equal(&txid) {
+		t.Error("FAIL")
+	}
+
+	// the genesis block has only a coinbase transaction
+	data, _ := hex.DecodeString(genesisBlock)
+	b, err := ParseBlock(data)
+	if err != nil {
+		t.Error("FAIL")
+		return
+	}
+	txids, _ := b.Txids()
+	root = CoinbaseMerkle(txids[0])
+	if !root.Equal(&b.Hdr.Merkle) {
+		fmt.Printf("%s (expected) %s (actual)\n", b.Hdr.Merkle.String(), root.String())
+		t.Error("FAIL")
+	}
+
+}
+
+func TestSetMerkleFromTxids(t *testing.T) {
+
+	txids := hashList(txids100000)
+	h := &Hdr{}
+	h.SetMerkleFromTxids(txids)
+	root := MerkleRoot(txids)
+	if !h.Merkle.Equal(&root) {
+		t.Error("FAIL")
+	}
+	if h.Merkle.String() != "f3e94742aca4b5ef85488dc37c06c3282295ffec960994b2c0d5ac2a25a95766" {
+		t.Error("FAIL")
+	}
+
+	// the genesis block
+	g := Mainnet.Genesis()
+	merkle := g.Merkle
+	g.SetMerkleFromTxids([]sha2.Hash256{merkle})
+	if !g.Merkle.Equal(&merkle) || !g.CheckProofOfWork() {
+		t.Error("FAIL")
+	}
+
+}
@@ -0,0 +1,132 @@
+//-----------------------------------------------------------------------------
+/*
+
+Incremental Merkle Tree
+
+The tree keeps the nodes for each complete pair of children, so adding a
+leaf only hashes the new path up the tree. Nodes on the right hand edge that
+depend on a duplicated (odd) hash are computed when the root is needed.
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"errors"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+//-----------------------------------------------------------------------------
+
+// MerkleTree is a merkle tree that txids can be added to.
+// The zero value is an empty tree.
+type MerkleTree struct {
+	levels [][]sha2.Hash256 // levels[0] is the leaves
+}
+
+// level returns the complete nodes at level k.
+func (t *MerkleTree) level(k int) []sha2.Hash256 {
+	if k < len(t.levels) {
+		return t.levels[k]
+	}
+	return nil
+}
+
+// Len returns the number of leaves in the tree.
+func (t *MerkleTree) Len() int {
+	return len(t.level(0))
+}
+
+// Add adds a txid to the tree.
+func (t *MerkleTree) Add(txid sha2.Hash256) {
+	x := txid
+	for k := 0; ; k++ {
+		if k == len(t.levels) {
+			t.levels = append(t.levels, nil)
+		}
+		t.levels[k] = append(t.levels[k], x)
+		n := len(t.levels[k])
+		if n&1 == 1 {
+			return
+		}
+		x = merkleParent(&t.levels[k][n-2], &t.levels[k][n-1])
+	}
+}
+
+// edge returns the right hand edge node for each level up to the root.
+// The edge node follows the complete nodes of a level, it is nil if the
+// level has no trailing node.
+func (t *MerkleTree) edge() []*sha2.Hash256 {
+	edge := []*sha2.Hash256{nil}
+	for k := 0; ; k++ {
+		lv := t.level(k)
+		tail := edge[k]
+		n := len(lv)
+		if tail != nil {
+			n++
+		}
+		if n <= 1 {
+			return edge
+		}
+		// nodes left over after the complete pairs
+		var x *sha2.Hash256
+		rem := lv[len(lv)&^1:]
+		switch {
+		case len(rem) == 1 && tail != nil:
+			p := merkleParent(&rem[0], tail)
+			x = &p
+		case len(rem) == 1:
+			p := merkleParent(&rem[0], &rem[0])
+			x = &p
+		case tail != nil:
+			p := merkleParent(tail, tail)
+			x = &p
+		}
+		edge = append(edge, x)
+	}
+}
+
+// Root returns the merkle root of the txids in the tree.
+// It is the same as MerkleRoot for the txids.
+func (t *MerkleTree) Root() sha2.Hash256 {
+	if t.Len() == 0 {
+		panic("empty merkle tree")
+	}
+	edge := t.edge()
+	k := len(edge) - 1
+	if edge[k] != nil {
+		return *edge[k]
+	}
+	return t.level(k)[0]
+}
+
+// Proof returns the merkle path for the txid at index.
+// It is the same as MerkleProof for the txids.
+func (t *MerkleTree) Proof(index int) ([]sha2.Hash256, []bool, error) {
+	if index < 0 || index >= t.Len() {
+		return nil, nil, errors.New("index out of range")
+	}
+	var path []sha2.Hash256
+	var directions []bool
+	edge := t.edge()
+	for k := 0; k < len(edge)-1; k++ {
+		lv := t.level(k)
+		j := index ^ 1
+		if j > len(lv) || (j == len(lv) && edge[k] == nil) {
+			// no sibling, the node is duplicated
+			j = index
+		}
+		if j < len(lv) {
+			path = append(path, lv[j])
+		} else {
+			path = append(path, *edge[k])
+		}
+		directions = append(directions, index&1 == 0)
+		index >>= 1
+	}
+	return path, directions, nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,72 @@
+package block
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+func TestMerkleTree(t *testing.T) {
+
+	var tree MerkleTree
+	var txids []sha2.Hash256
+
+	for n := 1; n <= 40; n++ {
+
+		var txid sha2.Hash256
+		for j := range txid {
+			txid[j] = rand.Uint32()
+		}
+		txids = append(txids, txid)
+		tree.Add(txid)
+
+		if tree.Len() != n {
+			t.Error("FAIL")
+		}
+
+		root := tree.Root()
+		x := MerkleRoot(txids)
+		if !root.Equal(&x) {
+			fmt.Printf("n %d: root mismatch\n", n)
+			t.Error("FAIL")
+		}
+
+		for i := range txids {
+			path, directions, err := tree.Proof(i)
+			if err != nil {
+				t.Error("FAIL")
+				continue
+			}
+			p, d, _ := MerkleProof(txids, i)
+			if len(path) != len(p) || len(directions) != len(d) {
+				fmt.Printf("n %d index %d: proof mismatch\n", n, i)
+				t.Error("FAIL")
+				continue
+			}
+			for k := range p {
+				if !path[k].Equal(&p[k]) || directions[k] != d[k] {
+					fmt.Printf("n %d index %d: proof mismatch\n", n, i)
+					t.Error("FAIL")
+				}
+			}
+		}
+
+		_, _, err := tree.Proof(n)
+		if err == nil {
+			t.Error("FAIL")
+		}
+	}
+
+	// block 100000
+	tree = MerkleTree{}
+	for _, txid := range hashList(txids100000) {
+		tree.Add(txid)
+	}
+	root := tree.Root()
+	if root.String() != "f3e94742aca4b5ef85488dc37c06c3282295ffec960994b2c0d5ac2a25a95766" {
+		t.Error("FAIL")
+	}
+
+}
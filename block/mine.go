@@ -0,0 +1,228 @@
+//-----------------------------------------------------------------------------
+/*
+
+Block Mining
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"context"
+	"encoding/binary"
+	"math/bits"
+	"sync"
+	"time"
+
+	"github.com/deadsy/bcx/sha2"
+	"github.com/deadsy/bcx/util"
+)
+
+//-----------------------------------------------------------------------------
+
+// miner hashes a header for different nonce values.
+// The first 64 bytes of the header don't depend on the nonce, so the
+// SHA256 state after that chunk (the midstate) is computed once.
+type miner struct {
+	mid  sha2.Hash256 // state after the first 512 bit chunk
+	tail [64]byte     // second chunk: header bytes 64..79 and padding
+	buf  [64]byte     // chunk for the second SHA256 pass
+}
+
+func newMiner(h *Hdr) *miner {
+	m := &miner{}
+	x := h.Bytes()
+	m.mid = sha2.Init256()
+	m.mid.Add512(x[:64])
+	copy(m.tail[:], x[64:])
+	m.tail[16] = 0x80
+	m.tail[62] = (HdrSize * 8) >> 8
+	m.tail[63] = (HdrSize * 8) & 0xff
+	m.buf[sha2.Size256] = 0x80
+	m.buf[62] = (sha2.Size256 * 8) >> 8
+	m.buf[63] = (sha2.Size256 * 8) & 0xff
+	return m
+}
+
+// hash returns the header hash for a nonce.
+func (m *miner) hash(nonce uint32) sha2.Hash256 {
+	binary.LittleEndian.PutUint32(m.tail[12:16], nonce)
+	x := m.mid.Clone()
+	x.Add512(m.tail[:])
+	x.Copy(m.buf[:sha2.Size256])
+	x = sha2.Init256()
+	x.Add512(m.buf[:])
+	return x
+}
+
+// hashTarget returns the target for the compact "bits" encoding as a hash.
+// It returns false if no hash can meet the target. A target that doesn't fit
+// in 256 bits is limited to the largest hash.
+func hashTarget(nbits uint32) (sha2.Hash256, bool) {
+	var h sha2.Hash256
+	t := BitsToTarget(nbits)
+	if t.Sign() < 0 {
+		return h, false
+	}
+	var b [sha2.Size256]byte
+	if t.BitLen() > 8*sha2.Size256 {
+		for i := range b {
+			b[i] = 0xff
+		}
+	} else {
+		// little-endian, as for hashToBig
+		t.FillBytes(b[:])
+		util.ReverseInPlace(b[:])
+	}
+	h, _ = sha2.FromBytes(b[:])
+	return h, true
+}
+
+// meetsTarget returns true if the hash is <= the target.
+// The words are compared from most to least significant without allocating.
+func meetsTarget(hash, target *sha2.Hash256) bool {
+	for i := len(hash) - 1; i >= 0; i-- {
+		x := bits.ReverseBytes32(hash[i])
+		y := bits.ReverseBytes32(target[i])
+		if x != y {
+			return x < y
+		}
+	}
+	return true
+}
+
+//-----------------------------------------------------------------------------
+
+// progressInterval is the number of hashes between progress reports.
+const progressInterval = 1 << 20
+
+// mineRange searches nonces lo..hi-1 for a header hash that meets the target.
+// If report is not nil it is called every interval hashes.
+func mineRange(h *Hdr, lo, hi, interval uint64, report func(nonce uint32, hashes uint64)) (uint32, bool) {
+	// a negative target is searched, but never met
+	target, ok := hashTarget(h.Target)
+	m := newMiner(h)
+	var hashes uint64
+	for nonce := lo; nonce < hi; nonce++ {
+		hash := m.hash(uint32(nonce))
+		if ok && meetsTarget(&hash, &target) {
+			h.Nonce = uint32(nonce)
+			return uint32(nonce), true
+		}
+		hashes++
+		if report != nil && hashes%interval == 0 {
+			report(uint32(nonce), hashes)
+		}
+	}
+	return 0, false
+}
+
+// Mine searches the nonce space for a header hash that meets the target.
+// It returns the first valid nonce and sets h.Nonce to it.
+// It returns false if no nonce meets the target.
+func Mine(h *Hdr) (uint32, bool) {
+	return mineRange(h, 0, 1<<32, progressInterval, nil)
+}
+
+// MineProgress is Mine with a progress report.
+// report is called every 2^20 hashes with the current nonce and the number
+// of hashes so far. It is called from the mining loop, so it should be quick.
+func MineProgress(h *Hdr, report func(nonce uint32, hashes uint64)) (uint32, bool) {
+	return mineRange(h, 0, 1<<32, progressInterval, report)
+}
+
+// mineExtended searches nonces 0..hi-1 for each time from h.Time to maxTime.
+func mineExtended(h *Hdr, maxTime uint32, hi uint64) (uint32, uint32, bool) {
+	for {
+		nonce, ok := mineRange(h, 0, hi, progressInterval, nil)
+		if ok {
+			return h.Time, nonce, true
+		}
+		if h.Time >= maxTime {
+			return 0, 0, false
+		}
+		h.Time++
+	}
+}
+
+// MineExtended searches the nonce space, and when it is exhausted increments
+// the header time (up to maxTime) and searches again. It returns the time and
+// nonce that meet the target, and sets them in the header.
+// It returns false if no time and nonce meet the target.
+func MineExtended(h *Hdr, maxTime uint32) (uint32, uint32, bool) {
+	return mineExtended(h, maxTime, 1<<32)
+}
+
+//-----------------------------------------------------------------------------
+
+// MineParallel searches the nonce space using a number of goroutines.
+// Each worker searches its own part of the nonce space. It returns the first
+// valid nonce found (not necessarily the lowest) and sets h.Nonce to it.
+// It returns false if no nonce meets the target.
+func MineParallel(h *Hdr, workers int) (uint32, bool) {
+	if workers < 1 {
+		workers = 1
+	}
+	target, ok := hashTarget(h.Target)
+	if !ok {
+		return 0, false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	found := make(chan uint32, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		lo := uint64(i) << 32 / uint64(workers)
+		hi := uint64(i+1) << 32 / uint64(workers)
+		go func() {
+			defer wg.Done()
+			m := newMiner(h)
+			for nonce := lo; nonce < hi; nonce++ {
+				// check for cancellation every 4096 hashes
+				if nonce&4095 == 0 && ctx.Err() != nil {
+					return
+				}
+				hash := m.hash(uint32(nonce))
+				if meetsTarget(&hash, &target) {
+					found <- uint32(nonce)
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case nonce := <-found:
+		h.Nonce = nonce
+		return nonce, true
+	default:
+		return 0, false
+	}
+}
+
+// BenchmarkHashRate hashes a header with incrementing nonces for a duration.
+// It returns the number of hashes and the hash rate in hashes per second.
+func BenchmarkHashRate(duration time.Duration) (uint64, float64) {
+	h := &Hdr{Version: 1, Target: MaxTargetBits}
+	m := newMiner(h)
+	var hashes uint64
+	start := time.Now()
+	for {
+		// check the time every 4096 hashes
+		for i := 0; i < 4096; i++ {
+			m.hash(uint32(hashes))
+			hashes++
+		}
+		elapsed := time.Since(start)
+		if elapsed >= duration {
+			return hashes, float64(hashes) / elapsed.Seconds()
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,186 @@
+package block
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+func TestMiner(t *testing.T) {
+
+	h := block125552()
+	m := newMiner(h)
+	for i := 0; i < 100; i++ {
+		h.Nonce = uint32(i)
+		x := m.hash(h.Nonce)
+		y := h.Hash()
+		if !x.Equal(&y) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestMine(t *testing.T) {
+
+	h := block125552()
+	h.Target = 0x1f00ffff // easy target
+
+	nonce, ok := Mine(h)
+	if !ok || h.Nonce != nonce || !h.CheckProofOfWork() {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestMeetsTarget(t *testing.T) {
+
+	h := block125552()
+	hash := h.Hash()
+	for _, nbits := range []uint32{h.Target, 0x1800ffff, 0x1d00ffff, 0x207fffff, 0x03123456, 0x2100ffff} {
+		target, ok := hashTarget(nbits)
+		if !ok {
+			t.Error("FAIL")
+			continue
+		}
+		if meetsTarget(&hash, &target) != (NewTargetFromBits(nbits).Cmp(hash) <= 0) {
+			t.Error("FAIL")
+		}
+		if !meetsTarget(&target, &target) {
+			t.Error("FAIL")
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		var x sha2.Hash256
+		for j := range x {
+			x[j] = rand.Uint32()
+		}
+		nbits := uint32(rand.Intn(0x20)+1)<<24 | uint32(rand.Intn(0x7fffff))
+		target, _ := hashTarget(nbits)
+		if meetsTarget(&x, &target) != (NewTargetFromBits(nbits).Cmp(x) <= 0) {
+			t.Error("FAIL")
+		}
+	}
+
+	// negative target
+	if _, ok := hashTarget(0x04923456); ok {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestBenchmarkHashRate(t *testing.T) {
+
+	hashes, rate := BenchmarkHashRate(10 * time.Millisecond)
+	if hashes == 0 || rate <= 0 {
+		t.Error("FAIL")
+	}
+
+}
+
+func BenchmarkHash(b *testing.B) {
+	h := block125552()
+	for i := 0; i < b.N; i++ {
+		h.Hash()
+	}
+}
+
+func BenchmarkMinerHash(b *testing.B) {
+	m := newMiner(block125552())
+	for i := 0; i < b.N; i++ {
+		m.hash(uint32(i))
+	}
+}
+
+func BenchmarkMeetsTarget(b *testing.B) {
+	h := block125552()
+	hash := h.Hash()
+	target, _ := hashTarget(h.Target)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		meetsTarget(&hash, &target)
+	}
+}
+
+func TestMineParallel(t *testing.T) {
+
+	h := block125552()
+	h.Target = 0x1f00ffff // easy target
+
+	nonce, ok := MineParallel(h, 4)
+	if !ok || h.Nonce != nonce || !h.CheckProofOfWork() {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestMineProgress(t *testing.T) {
+
+	// a negative target, so no nonce meets it
+	h := block125552()
+	h.Target = 0x04923456
+
+	var calls int
+	var last uint64
+	_, ok := mineRange(h, 100, 100+10*1024+5, 1024, func(nonce uint32, hashes uint64) {
+		calls++
+		if hashes != last+1024 || uint64(nonce) != 100+hashes-1 {
+			t.Error("FAIL")
+		}
+		last = hashes
+	})
+	if ok || calls != 10 {
+		t.Error("FAIL")
+	}
+
+	// found before the first report
+	h = block125552()
+	h.Target = 0x1f00ffff // easy target
+	calls = 0
+	nonce, ok := MineProgress(h, func(nonce uint32, hashes uint64) { calls++ })
+	if !ok || h.Nonce != nonce || !h.CheckProofOfWork() || calls != 0 {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestMineExtended(t *testing.T) {
+
+	// 16 nonces per time with an easy target
+	h := block125552()
+	h.Target = 0x2000ffff
+	start := h.Time
+	tm, nonce, ok := mineExtended(h, start+1000, 16)
+	if !ok || h.Time != tm || h.Nonce != nonce || nonce >= 16 || !h.CheckProofOfWork() {
+		t.Error("FAIL")
+	}
+	// no earlier time has a solution
+	x := block125552()
+	x.Target = h.Target
+	for x.Time = start; x.Time < tm; x.Time++ {
+		for x.Nonce = 0; x.Nonce < 16; x.Nonce++ {
+			if x.CheckProofOfWork() {
+				t.Error("FAIL")
+			}
+		}
+	}
+
+	// no solution in the time window
+	h = block125552()
+	h.Target = 0x04923456
+	_, _, ok = mineExtended(h, h.Time+4, 16)
+	if ok || h.Time != start+4 {
+		t.Error("FAIL")
+	}
+
+	h = block125552()
+	h.Target = 0x1f00ffff // easy target
+	tm, nonce, ok = MineExtended(h, h.Time+10)
+	if !ok || h.Time != tm || h.Nonce != nonce || !h.CheckProofOfWork() {
+		t.Error("FAIL")
+	}
+
+}
@@ -0,0 +1,70 @@
+//-----------------------------------------------------------------------------
+/*
+
+Network Parameters
+
+https://en.bitcoin.it/wiki/Protocol_documentation#Message_structure
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+//-----------------------------------------------------------------------------
+
+// Network holds the constants that differ between bitcoin networks.
+type Network struct {
+	name         string
+	magic        uint32 // message and block file marker
+	p2pkhVersion byte   // base58 P2PKH address version
+	p2shVersion  byte   // base58 P2SH address version
+	wifVersion   byte   // WIF private key version
+	bech32HRP    string // bech32 human readable part
+	powLimitBits uint32 // compact form of the proof of work limit
+	genesisTime  uint32 // genesis block time
+	genesisNonce uint32 // genesis block nonce
+}
+
+// Predefined networks.
+var (
+	Mainnet = &Network{"mainnet", 0xd9b4bef9, 0x00, 0x05, 0x80, "bc", MaxTargetBits, MinTime, 2083236893}
+	Testnet = &Network{"testnet3", 0x0709110b, 0x6f, 0xc4, 0xef, "tb", MaxTargetBits, 1296688602, 414098458}
+	Regtest = &Network{"regtest", 0xdab5bffa, 0x6f, 0xc4, 0xef, "bcrt", 0x207fffff, 1296688602, 2}
+)
+
+func (n *Network) String() string {
+	return n.name
+}
+
+// Magic returns the message start value (little-endian on the wire).
+func (n *Network) Magic() uint32 {
+	return n.magic
+}
+
+// P2PKHVersion returns the base58 P2PKH address version byte.
+func (n *Network) P2PKHVersion() byte {
+	return n.p2pkhVersion
+}
+
+// P2SHVersion returns the base58 P2SH address version byte.
+func (n *Network) P2SHVersion() byte {
+	return n.p2shVersion
+}
+
+// WIFVersion returns the WIF private key version byte.
+func (n *Network) WIFVersion() byte {
+	return n.wifVersion
+}
+
+// Bech32HRP returns the bech32 address human readable part.
+func (n *Network) Bech32HRP() string {
+	return n.bech32HRP
+}
+
+// PowLimitBits returns the compact form of the proof of work limit.
+// The genesis block uses this target.
+func (n *Network) PowLimitBits() uint32 {
+	return n.powLimitBits
+}
+
+//-----------------------------------------------------------------------------
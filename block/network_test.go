@@ -0,0 +1,35 @@
+package block
+
+import (
+	"testing"
+)
+
+func TestNetwork(t *testing.T) {
+
+	tests := []struct {
+		net   *Network
+		name  string
+		magic uint32
+		p2pkh byte
+		p2sh  byte
+		wif   byte
+		hrp   string
+	}{
+		{Mainnet, "mainnet", 0xd9b4bef9, 0x00, 0x05, 0x80, "bc"},
+		{Testnet, "testnet3", 0x0709110b, 0x6f, 0xc4, 0xef, "tb"},
+		{Regtest, "regtest", 0xdab5bffa, 0x6f, 0xc4, 0xef, "bcrt"},
+	}
+
+	for _, test := range tests {
+		n := test.net
+		if n.String() != test.name ||
+			n.Magic() != test.magic ||
+			n.P2PKHVersion() != test.p2pkh ||
+			n.P2SHVersion() != test.p2sh ||
+			n.WIFVersion() != test.wif ||
+			n.Bech32HRP() != test.hrp {
+			t.Error("FAIL")
+		}
+	}
+
+}
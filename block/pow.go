@@ -0,0 +1,191 @@
+//-----------------------------------------------------------------------------
+/*
+
+Proof of Work
+
+https://developer.bitcoin.org/reference/block_chain.html#target-nbits
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/deadsy/bcx/sha2"
+	"github.com/deadsy/bcx/util"
+)
+
+//-----------------------------------------------------------------------------
+
+// MaxTargetBits is the compact form of the proof of work limit.
+// It is the genesis block target, and the difficulty 1 target.
+const MaxTargetBits = 0x1d00ffff
+
+// PowLimit returns the proof of work limit (the easiest allowed target).
+// Testnet also uses it for blocks mined under the 20 minute minimum
+// difficulty rule.
+func (n *Network) PowLimit() *big.Int {
+	return BitsToTarget(n.powLimitBits)
+}
+
+// MaxTarget returns the mainnet or testnet proof of work limit.
+// Mainnet and testnet have the same limit.
+//
+// Deprecated: Use Network.PowLimit.
+func MaxTarget(mainnet bool) *big.Int {
+	if mainnet {
+		return Mainnet.PowLimit()
+	}
+	return Testnet.PowLimit()
+}
+
+//-----------------------------------------------------------------------------
+
+// BitsToTarget expands the compact "bits" encoding of a target.
+// The top byte is a base 256 exponent and the low 23 bits are the mantissa.
+// Bit 23 is a sign bit, a set sign bit with a non-zero mantissa gives a
+// negative target (which no hash can satisfy).
+func BitsToTarget(bits uint32) *big.Int {
+	exponent := uint(bits >> 24)
+	mantissa := int64(bits & 0x007fffff)
+	t := new(big.Int)
+	if exponent <= 3 {
+		t.SetInt64(mantissa >> (8 * (3 - exponent)))
+	} else {
+		t.SetInt64(mantissa)
+		t.Lsh(t, 8*(exponent-3))
+	}
+	if mantissa != 0 && (bits&0x00800000) != 0 {
+		t.Neg(t)
+	}
+	return t
+}
+
+// TargetToBits returns the compact "bits" encoding of a target.
+// The mantissa is normalized so its top bit (the sign bit) is clear.
+func TargetToBits(target *big.Int) uint32 {
+	t := new(big.Int).Abs(target)
+	size := uint((t.BitLen() + 7) / 8)
+	var compact uint32
+	if size <= 3 {
+		compact = uint32(t.Uint64() << (8 * (3 - size)))
+	} else {
+		compact = uint32(t.Rsh(t, 8*(size-3)).Uint64())
+	}
+	// the sign bit is set, use a larger exponent
+	if compact&0x00800000 != 0 {
+		compact >>= 8
+		size++
+	}
+	compact |= uint32(size) << 24
+	if target.Sign() < 0 && compact&0x007fffff != 0 {
+		compact |= 0x00800000
+	}
+	return compact
+}
+
+// hashToBig converts a hash to an integer for comparison with a target.
+// The SHA256 digest bytes (Hash256.Bytes) are a little-endian integer, so
+// the bytes are reversed before loading them into the big-endian big.Int.
+// The reversed byte order is the display order (Hash256.String), which is why
+// a valid block hash is displayed with leading zeroes.
+func hashToBig(h *sha2.Hash256) *big.Int {
+	b := h.Bytes()
+	util.ReverseInPlace(b[:])
+	return new(big.Int).SetBytes(b[:])
+}
+
+//-----------------------------------------------------------------------------
+
+// Target is a proof of work target.
+// It keeps the byte order conversions between hashes, compact bits and
+// integers in one place.
+type Target struct {
+	t *big.Int
+}
+
+// NewTargetFromBits returns the target for the compact "bits" encoding.
+func NewTargetFromBits(bits uint32) *Target {
+	return &Target{t: BitsToTarget(bits)}
+}
+
+// NewTargetFromBig returns a target for an integer value.
+// The value is copied.
+func NewTargetFromBig(x *big.Int) *Target {
+	return &Target{t: new(big.Int).Set(x)}
+}
+
+// Big returns a copy of the target integer.
+func (t *Target) Big() *big.Int {
+	return new(big.Int).Set(t.t)
+}
+
+// Bits returns the compact "bits" encoding of the target.
+func (t *Target) Bits() uint32 {
+	return TargetToBits(t.t)
+}
+
+// Difficulty returns the ratio of the difficulty 1 target (MaxTargetBits)
+// to the target. A zero (or negative) target returns +Inf.
+func (t *Target) Difficulty() float64 {
+	if t.t.Sign() <= 0 {
+		return math.Inf(1)
+	}
+	d := new(big.Float).SetInt(BitsToTarget(MaxTargetBits))
+	d.Quo(d, new(big.Float).SetInt(t.t))
+	f, _ := d.Float64()
+	return f
+}
+
+// Cmp compares a hash with the target.
+// It returns -1 if the hash is below the target, 0 if it is equal and +1 if
+// it is above the target. A hash meets the target if Cmp returns <= 0.
+func (t *Target) Cmp(hash sha2.Hash256) int {
+	return hashToBig(&hash).Cmp(t.t)
+}
+
+//-----------------------------------------------------------------------------
+
+// HashMeetsTarget returns true if the hash is <= the target for the bits.
+func HashMeetsTarget(hash sha2.Hash256, bits uint32) bool {
+	return NewTargetFromBits(bits).Cmp(hash) <= 0
+}
+
+// CheckProofOfWork returns true if the header hash is <= the header target.
+func (h *Hdr) CheckProofOfWork() bool {
+	return HashMeetsTarget(h.Hash(), h.Target)
+}
+
+// Difficulty returns the ratio of the difficulty 1 target (MaxTargetBits)
+// to the target for the bits. A zero (or negative) target returns +Inf.
+func Difficulty(bits uint32) float64 {
+	return NewTargetFromBits(bits).Difficulty()
+}
+
+//-----------------------------------------------------------------------------
+
+// HeaderWork returns the expected number of hashes to meet the target for
+// the bits, 2^256 / (target + 1). A zero (or negative) target has no work.
+func HeaderWork(bits uint32) *big.Int {
+	t := BitsToTarget(bits)
+	if t.Sign() <= 0 {
+		return new(big.Int)
+	}
+	w := new(big.Int).Lsh(big.NewInt(1), 256)
+	return w.Quo(w, t.Add(t, big.NewInt(1)))
+}
+
+// ChainWork returns the total work for a list of headers.
+// The best chain is the one with the most work, not the most headers.
+func ChainWork(headers []*Hdr) *big.Int {
+	w := new(big.Int)
+	for _, h := range headers {
+		w.Add(w, HeaderWork(h.Target))
+	}
+	return w
+}
+
+//-----------------------------------------------------------------------------
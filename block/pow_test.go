@@ -0,0 +1,225 @@
+package block
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+var bitsTests = []struct {
+	bits   uint32
+	target string
+}{
+	{0x1d00ffff, "ffff0000000000000000000000000000000000000000000000000000"}, // genesis
+	{0x1a44b9f2, "44b9f20000000000000000000000000000000000000000000000"},     // block 125552
+	{0x01003456, "0"},
+	{0x01123456, "12"},
+	{0x02123456, "1234"},
+	{0x03123456, "123456"},
+	{0x04123456, "12345600"},
+	{0x04923456, "-12345600"},
+	{0x05009234, "92340000"},
+	{0x20123456, "1234560000000000000000000000000000000000000000000000000000000000"},
+}
+
+func TestBitsToTarget(t *testing.T) {
+
+	for _, test := range bitsTests {
+		expected, _ := new(big.Int).SetString(test.target, 16)
+		x := BitsToTarget(test.bits)
+		if x.Cmp(expected) != 0 {
+			fmt.Printf("%08x: %x (expected) %x (actual)\n", test.bits, expected, x)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestCheckProofOfWork(t *testing.T) {
+
+	h := block125552()
+	if !h.CheckProofOfWork() {
+		t.Error("FAIL")
+	}
+
+	h.Nonce++
+	if h.CheckProofOfWork() {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestDifficulty(t *testing.T) {
+
+	if Difficulty(0x1d00ffff) != 1 {
+		t.Error("FAIL")
+	}
+
+	// block 125552
+	d := Difficulty(440711666)
+	if math.Abs(d-244112.49) > 0.01 {
+		fmt.Printf("244112.49 (expected) %f (actual)\n", d)
+		t.Error("FAIL")
+	}
+
+	if !math.IsInf(Difficulty(0), 1) {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestTargetToBits(t *testing.T) {
+
+	// normalized bits values round trip
+	for _, bits := range []uint32{0x1d00ffff, 0x1a44b9f2, 0x1b04864c, 0x1d00d86a, 0x207fffff, 0x04923456, 0x05009234, 0x03123456} {
+		x := TargetToBits(BitsToTarget(bits))
+		if x != bits {
+			fmt.Printf("%08x (expected) %08x (actual)\n", bits, x)
+			t.Error("FAIL")
+		}
+	}
+
+	// non-normalized
+	if TargetToBits(big.NewInt(0x12)) != 0x01120000 {
+		t.Error("FAIL")
+	}
+	if TargetToBits(big.NewInt(0x80)) != 0x02008000 {
+		t.Error("FAIL")
+	}
+	if TargetToBits(big.NewInt(0)) != 0 {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestHashMeetsTarget(t *testing.T) {
+
+	// block 125552
+	hash, _ := sha2.FromStringReversed("00000000000000001e8d6829a8a21adc5d38d0a473b144b6765798e61f98bd1d")
+	if !HashMeetsTarget(hash, 440711666) {
+		t.Error("FAIL")
+	}
+
+	// a harder target
+	if HashMeetsTarget(hash, 0x1800ffff) {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestTarget(t *testing.T) {
+
+	// round trip from bits
+	for _, test := range bitsTests {
+		x := NewTargetFromBits(test.bits)
+		if x.Big().Text(16) != test.target {
+			fmt.Printf("%s (expected) %s (actual)\n", test.target, x.Big().Text(16))
+			t.Error("FAIL")
+		}
+		y := NewTargetFromBig(x.Big())
+		if y.Bits() != TargetToBits(BitsToTarget(test.bits)) {
+			fmt.Printf("%08x (expected) %08x (actual)\n", TargetToBits(BitsToTarget(test.bits)), y.Bits())
+			t.Error("FAIL")
+		}
+	}
+
+	if NewTargetFromBits(0x1d00ffff).Difficulty() != 1 {
+		t.Error("FAIL")
+	}
+
+	// block 125552
+	hash, _ := sha2.FromStringReversed("00000000000000001e8d6829a8a21adc5d38d0a473b144b6765798e61f98bd1d")
+	if NewTargetFromBits(440711666).Cmp(hash) >= 0 {
+		t.Error("FAIL")
+	}
+	if NewTargetFromBits(0x1800ffff).Cmp(hash) <= 0 {
+		t.Error("FAIL")
+	}
+
+	// a hash equal to the target
+	x := NewTargetFromBits(0x1d00ffff)
+	hash, _ = sha2.FromStringReversed(fmt.Sprintf("%064s", x.Big().Text(16)))
+	if x.Cmp(hash) != 0 {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestMaxTarget(t *testing.T) {
+
+	if BitsToTarget(0x1d00ffff).Cmp(MaxTarget(true)) != 0 {
+		t.Error("FAIL")
+	}
+	if MaxTarget(false).Cmp(MaxTarget(true)) != 0 {
+		t.Error("FAIL")
+	}
+	if TargetToBits(MaxTarget(true)) != MaxTargetBits {
+		t.Error("FAIL")
+	}
+	if Difficulty(MaxTargetBits) != 1 {
+		t.Error("FAIL")
+	}
+
+	// the returned value can be modified
+	MaxTarget(true).SetInt64(0)
+	if MaxTarget(true).Sign() == 0 {
+		t.Error("FAIL")
+	}
+
+	for _, test := range []struct {
+		net  *Network
+		bits uint32
+	}{
+		{Mainnet, MaxTargetBits},
+		{Testnet, MaxTargetBits},
+		{Regtest, 0x207fffff},
+	} {
+		if test.net.PowLimitBits() != test.bits ||
+			test.net.PowLimit().Cmp(BitsToTarget(test.bits)) != 0 {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestHeaderWork(t *testing.T) {
+
+	// difficulty 1
+	w := HeaderWork(MaxTargetBits)
+	expected := big.NewInt(0x100010001)
+	if w.Cmp(expected) != 0 {
+		fmt.Printf("%x (expected) %x (actual)\n", expected, w)
+		t.Error("FAIL")
+	}
+
+	// a quarter of the target is four times the work
+	t0 := Mainnet.PowLimit()
+	t1 := new(big.Int).Rsh(t0, 2)
+	w0 := HeaderWork(TargetToBits(t0))
+	w1 := HeaderWork(TargetToBits(t1))
+	r := new(big.Float).Quo(new(big.Float).SetInt(w1), new(big.Float).SetInt(w0))
+	f, _ := r.Float64()
+	if math.Abs(f-4) > 1e-6 {
+		fmt.Printf("%f (actual)\n", f)
+		t.Error("FAIL")
+	}
+
+	// no work for a negative target
+	if HeaderWork(0x04923456).Sign() != 0 {
+		t.Error("FAIL")
+	}
+
+	// chain work is the sum
+	headers := []*Hdr{{Target: MaxTargetBits}, {Target: TargetToBits(t1)}}
+	cw := ChainWork(headers)
+	if cw.Cmp(new(big.Int).Add(w0, w1)) != 0 {
+		t.Error("FAIL")
+	}
+	if ChainWork(nil).Sign() != 0 {
+		t.Error("FAIL")
+	}
+
+}
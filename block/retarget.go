@@ -0,0 +1,51 @@
+//-----------------------------------------------------------------------------
+/*
+
+Difficulty Retargeting
+
+The target is adjusted every 2016 blocks so that blocks are found
+every 10 minutes on average.
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+import (
+	"math/big"
+)
+
+//-----------------------------------------------------------------------------
+
+// RetargetInterval is the number of blocks between target adjustments.
+const RetargetInterval = 2016
+
+// TargetTimespan is the expected time (in seconds) for RetargetInterval blocks.
+const TargetTimespan = 14 * 24 * 60 * 60
+
+// NextBits returns the bits for the next retarget interval.
+// actualTimespan is the time taken (in seconds) by the previous interval.
+func NextBits(oldBits uint32, actualTimespan int64) uint32 {
+
+	// limit the adjustment to a factor of 4
+	if actualTimespan < TargetTimespan/4 {
+		actualTimespan = TargetTimespan / 4
+	}
+	if actualTimespan > TargetTimespan*4 {
+		actualTimespan = TargetTimespan * 4
+	}
+
+	t := BitsToTarget(oldBits)
+	t.Mul(t, big.NewInt(actualTimespan))
+	t.Quo(t, big.NewInt(TargetTimespan))
+
+	// limit to the proof of work limit
+	limit := Mainnet.PowLimit()
+	if t.Cmp(limit) > 0 {
+		t = limit
+	}
+
+	return TargetToBits(t)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,38 @@
+package block
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNextBits(t *testing.T) {
+
+	// the first retarget at block 32256
+	// block 30240 time 1261130161, block 32255 time 1262152739
+	x := NextBits(0x1d00ffff, 1262152739-1261130161)
+	if x != 0x1d00d86a {
+		fmt.Printf("1d00d86a (expected) %08x (actual)\n", x)
+		t.Error("FAIL")
+	}
+
+	// can't go above the proof of work limit
+	x = NextBits(0x1d00ffff, TargetTimespan*2)
+	if x != 0x1d00ffff {
+		t.Error("FAIL")
+	}
+
+	// clamped to 1/4 of the timespan
+	x = NextBits(0x1c3fffc0, 1)
+	if x != 0x1c0ffff0 {
+		fmt.Printf("1c0ffff0 (expected) %08x (actual)\n", x)
+		t.Error("FAIL")
+	}
+
+	// clamped to 4x the timespan
+	x = NextBits(0x1c0ffff0, TargetTimespan*100)
+	if x != 0x1c3fffc0 {
+		fmt.Printf("1c3fffc0 (expected) %08x (actual)\n", x)
+		t.Error("FAIL")
+	}
+
+}
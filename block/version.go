@@ -0,0 +1,36 @@
+//-----------------------------------------------------------------------------
+/*
+
+Version Bits Signalling
+
+https://github.com/bitcoin/bips/blob/master/bip-0009.mediawiki
+
+*/
+//-----------------------------------------------------------------------------
+
+package block
+
+//-----------------------------------------------------------------------------
+
+// BIP9 version field values
+const (
+	bip9TopMask = 0xe0000000 // top 3 bits
+	bip9TopBits = 0x20000000 // top 3 bits are 001
+	bip9NumBits = 29         // signalling bits 0..28
+)
+
+// IsBIP9 returns true if the header version uses the BIP9 scheme.
+func (h *Hdr) IsBIP9() bool {
+	return h.Version&bip9TopMask == bip9TopBits
+}
+
+// SignalsBit returns true if the header signals for a BIP9 version bit.
+// It returns false if the version doesn't use the BIP9 scheme.
+func (h *Hdr) SignalsBit(bit uint) bool {
+	if bit >= bip9NumBits || !h.IsBIP9() {
+		return false
+	}
+	return h.Version&(1<<bit) != 0
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,48 @@
+package block
+
+import (
+	"testing"
+)
+
+func TestSignalsBit(t *testing.T) {
+
+	tests := []struct {
+		version uint32
+		bip9    bool
+		bits    []uint // bits that are signalled
+	}{
+		{0x20000002, true, []uint{1}},        // segwit
+		{0x20000001, true, []uint{0}},        // csv
+		{0x3fffffff, true, []uint{0, 5, 28}}, // all bits
+		{0x20000000, true, nil},              // no bits
+		{0x00000004, false, nil},             // version 4
+		{0x60000002, false, nil},             // top bits 011
+		{0xe0000002, false, nil},             // top bits 111
+	}
+
+	for _, test := range tests {
+		h := &Hdr{Version: test.version}
+		if h.IsBIP9() != test.bip9 {
+			t.Error("FAIL")
+		}
+		n := 0
+		for bit := uint(0); bit < 32; bit++ {
+			if h.SignalsBit(bit) {
+				n++
+			}
+		}
+		for _, bit := range test.bits {
+			if !h.SignalsBit(bit) {
+				t.Error("FAIL")
+			}
+		}
+		if test.version == 0x3fffffff {
+			if n != 29 {
+				t.Error("FAIL")
+			}
+		} else if n != len(test.bits) {
+			t.Error("FAIL")
+		}
+	}
+
+}
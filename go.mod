@@ -1,3 +1,5 @@
 module github.com/deadsy/bcx
 
-go 1.17
+go 1.18
+
+require golang.org/x/crypto v0.17.0
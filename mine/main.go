@@ -10,6 +10,7 @@ https://www.blockchain.com/btc/block/00000000000000001e8d6829a8a21adc5d38d0a473b
 package main
 
 import (
+	"encoding/binary"
 	"fmt"
 	"log"
 	"time"
@@ -21,12 +22,12 @@ import (
 
 func mine() error {
 
-	prev, err := sha2.FromString("81cd02ab7e569e8bcd9317e2fe99f2de44d49ab2b8851ba4a308000000000000")
+	prev, err := sha2.FromStringReversed("00000000000008a3a41b85b8b29ad444def299fee21793cd8b9e567eab02cd81")
 	if err != nil {
 		return err
 	}
 
-	merkle, err := sha2.FromString("e320b6c2fffc8d750423db8b1eb942ae710e951ed797f7affc8892b0f1fc122b")
+	merkle, err := sha2.FromStringReversed("2b12fcf1b09288fcaff797d71e950e71ae42b91e8bdb2304758dfcffc2b620e3")
 	if err != nil {
 		return err
 	}
@@ -41,22 +42,49 @@ func mine() error {
 	fmt.Printf("time: %d\n", t.Unix())
 
 	version := uint32(1)
-	time := uint32(t.Unix() + 31)
 	target := uint32(440711666) // bits
 	nonce := uint32(2504433986)
 
-	h := block.New(&prev, &merkle, version, time, target, nonce)
+	h := block.New(&prev, &merkle, version, 0, target, nonce)
+	h.SetTimestamp(t.Add(31 * time.Second))
 
 	x := h.Bytes()
 
 	fmt.Printf("header: %s\n", util.Dump8(x))
 
-	hash0 := sha2.Sha2_256(x)
+	hash0, hash1 := sha2.Sum256dVerbose(x)
 	fmt.Printf("hash0: %s\n", util.Dump8(hash0[:]))
-
-	hash1 := sha2.Sha2_256(hash0[:])
 	fmt.Printf("hash1: %s\n", util.Dump8(hash1[:]))
 
+	hash := h.Hash()
+	fmt.Printf("hash: %s\n", hash.String())
+
+	// The first 64 bytes of the header don't change with the nonce.
+	// Hash them once and reuse the midstate for each nonce.
+	mid := sha2.Init256()
+	mid.Add512(x[:64])
+
+	// the second chunk is the last 16 header bytes with padding
+	var tail [64]byte
+	copy(tail[:], x[64:])
+	tail[16] = 0x80
+	tail[62] = (80 * 8) >> 8
+	tail[63] = (80 * 8) & 0xff
+
+	for n := nonce - 1000; n != nonce+1000; n++ {
+		binary.LittleEndian.PutUint32(tail[12:16], n)
+		s := mid.Clone()
+		s.Add512(tail[:])
+		hash0 := s.Bytes()
+		hash1 := sha2.Sha2_256(hash0[:])
+		// look for 8 leading zero bytes (display order)
+		if binary.LittleEndian.Uint64(hash1[24:]) == 0 {
+			fmt.Printf("nonce: %d\n", n)
+			fmt.Printf("hash1: %s\n", util.Dump8(hash1[:]))
+			break
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,25 @@
+//-----------------------------------------------------------------------------
+/*
+
+Hash160
+
+RIPEMD160(SHA256(x)), used for P2PKH and P2SH addresses.
+
+*/
+//-----------------------------------------------------------------------------
+
+package ripemd160
+
+import (
+	"github.com/deadsy/bcx/sha2"
+)
+
+//-----------------------------------------------------------------------------
+
+// Hash160 returns RIPEMD160(SHA256(data)).
+func Hash160(data []byte) [Size]byte {
+	x := sha2.Sha2_256(data)
+	return Ripemd160(x[:])
+}
+
+//-----------------------------------------------------------------------------
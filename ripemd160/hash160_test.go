@@ -0,0 +1,21 @@
+package ripemd160
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestHash160(t *testing.T) {
+
+	// genesis coinbase public key
+	pubkey, _ := hex.DecodeString("04678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5f")
+	expected := "62e907b15cbf27d5425399ebf6f0fb50ebb88f18"
+
+	x := Hash160(pubkey)
+	if hex.EncodeToString(x[:]) != expected {
+		fmt.Printf("%s (expected) %x (actual)\n", expected, x)
+		t.Error("FAIL")
+	}
+
+}
@@ -0,0 +1,172 @@
+//-----------------------------------------------------------------------------
+/*
+
+RIPEMD-160 Implementation
+
+https://homes.esat.kuleuven.be/~bosselae/ripemd160.html
+https://en.wikipedia.org/wiki/RIPEMD
+
+*/
+//-----------------------------------------------------------------------------
+
+package ripemd160
+
+import (
+	"math/bits"
+
+	"github.com/deadsy/bcx/util"
+)
+
+//-----------------------------------------------------------------------------
+
+const Size = 20
+
+type state [5]uint32
+
+func (x *state) Bytes() [Size]byte {
+	var out [Size]byte
+	util.Conv32to8LE(out[:], x[:])
+	return out
+}
+
+//-----------------------------------------------------------------------------
+
+// pad512 pads a slice to a multiple of 512 bits (64 bytes)
+// Unlike SHA256 the bit length is little-endian.
+// The padded message is a new slice, the input is not modified.
+func pad512(data []byte) []byte {
+
+	n := uint64(len(data))
+
+	pad := 64 - (n % 64)
+
+	if pad < 9 {
+		pad += 64
+	}
+
+	buf := make([]byte, n+pad)
+	copy(buf, data)
+	data = buf
+
+	data[n] = 0x80
+	end := n + pad - 1
+	n *= 8
+
+	data[end-7] = uint8(n >> 0)
+	data[end-6] = uint8(n >> 8)
+	data[end-5] = uint8(n >> 16)
+	data[end-4] = uint8(n >> 24)
+	data[end-3] = uint8(n >> 32)
+	data[end-2] = uint8(n >> 40)
+	data[end-1] = uint8(n >> 48)
+	data[end-0] = uint8(n >> 56)
+
+	return data
+}
+
+//-----------------------------------------------------------------------------
+
+var hInit = state{
+	0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476, 0xc3d2e1f0,
+}
+
+// message word selection (left line)
+var r0 = [80]uint8{
+	0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+	7, 4, 13, 1, 10, 6, 15, 3, 12, 0, 9, 5, 2, 14, 11, 8,
+	3, 10, 14, 4, 9, 15, 8, 1, 2, 7, 0, 6, 13, 11, 5, 12,
+	1, 9, 11, 10, 0, 8, 12, 4, 13, 3, 7, 15, 14, 5, 6, 2,
+	4, 0, 5, 9, 7, 12, 2, 10, 14, 1, 3, 8, 11, 6, 15, 13,
+}
+
+// message word selection (right line)
+var r1 = [80]uint8{
+	5, 14, 7, 0, 9, 2, 11, 4, 13, 6, 15, 8, 1, 10, 3, 12,
+	6, 11, 3, 7, 0, 13, 5, 10, 14, 15, 8, 12, 4, 9, 1, 2,
+	15, 5, 1, 3, 7, 14, 6, 9, 11, 8, 12, 2, 10, 0, 4, 13,
+	8, 6, 4, 1, 3, 11, 15, 0, 5, 12, 2, 13, 9, 7, 10, 14,
+	12, 15, 10, 4, 1, 5, 8, 7, 6, 2, 13, 14, 0, 3, 9, 11,
+}
+
+// rotate amounts (left line)
+var s0 = [80]uint8{
+	11, 14, 15, 12, 5, 8, 7, 9, 11, 13, 14, 15, 6, 7, 9, 8,
+	7, 6, 8, 13, 11, 9, 7, 15, 7, 12, 15, 9, 11, 7, 13, 12,
+	11, 13, 6, 7, 14, 9, 13, 15, 14, 8, 13, 6, 5, 12, 7, 5,
+	11, 12, 14, 15, 14, 15, 9, 8, 9, 14, 5, 6, 8, 6, 5, 12,
+	9, 15, 5, 11, 6, 8, 13, 12, 5, 12, 13, 14, 11, 8, 5, 6,
+}
+
+// rotate amounts (right line)
+var s1 = [80]uint8{
+	8, 9, 9, 11, 13, 15, 15, 5, 7, 7, 8, 11, 14, 14, 12, 6,
+	9, 13, 15, 7, 12, 8, 9, 11, 7, 7, 12, 7, 6, 15, 13, 11,
+	9, 7, 15, 11, 8, 6, 6, 14, 12, 13, 5, 14, 13, 13, 7, 5,
+	15, 5, 8, 11, 14, 14, 6, 14, 6, 9, 12, 9, 12, 5, 15, 8,
+	8, 5, 12, 9, 12, 5, 14, 6, 8, 13, 6, 5, 15, 13, 11, 11,
+}
+
+// round constants
+var k0 = [5]uint32{0x00000000, 0x5a827999, 0x6ed9eba1, 0x8f1bbcdc, 0xa953fd4e}
+var k1 = [5]uint32{0x50a28be6, 0x5c4dd124, 0x6d703ef3, 0x7a6d76e9, 0x00000000}
+
+// f is the bitwise function for a round
+func f(round int, x, y, z uint32) uint32 {
+	switch round {
+	case 0:
+		return x ^ y ^ z
+	case 1:
+		return (x & y) | (^x & z)
+	case 2:
+		return (x | ^y) ^ z
+	case 3:
+		return (x & z) | (y & ^z)
+	}
+	return x ^ (y | ^z)
+}
+
+func (x *state) add512(data []byte) {
+
+	// 16 little-endian words
+	var w [16]uint32
+	util.Conv8to32LE(w[:], data)
+
+	a0, b0, c0, d0, e0 := x[0], x[1], x[2], x[3], x[4]
+	a1, b1, c1, d1, e1 := x[0], x[1], x[2], x[3], x[4]
+
+	for j := 0; j < 80; j++ {
+		round := j / 16
+		// left line
+		t := bits.RotateLeft32(a0+f(round, b0, c0, d0)+w[r0[j]]+k0[round], int(s0[j])) + e0
+		a0, e0, d0, c0, b0 = e0, d0, bits.RotateLeft32(c0, 10), b0, t
+		// right line
+		t = bits.RotateLeft32(a1+f(4-round, b1, c1, d1)+w[r1[j]]+k1[round], int(s1[j])) + e1
+		a1, e1, d1, c1, b1 = e1, d1, bits.RotateLeft32(c1, 10), b1, t
+	}
+
+	t := x[1] + c0 + d1
+	x[1] = x[2] + d0 + e1
+	x[2] = x[3] + e0 + a1
+	x[3] = x[4] + a0 + b1
+	x[4] = x[0] + b0 + c1
+	x[0] = t
+}
+
+// Ripemd160 returns the RIPEMD-160 hash of the data.
+func Ripemd160(data []byte) [Size]byte {
+
+	// pad to a multiple of 512 bits
+	data = pad512(data)
+
+	x := hInit
+
+	// for each 512 bit chunk
+	for i := 0; i < len(data)/64; i++ {
+		j := i * 64
+		x.add512(data[j : j+64])
+	}
+
+	return x.Bytes()
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,79 @@
+package ripemd160
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ripemd160"
+)
+
+// https://homes.esat.kuleuven.be/~bosselae/ripemd160.html
+var hashTests = []struct {
+	in  string
+	out string
+}{
+	{"", "9c1185a5c5e9fc54612808977ee8f548b2258d31"},
+	{"a", "0bdc9d2d256b3ee9daae347be6f4dc835a467ffe"},
+	{"abc", "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc"},
+	{"message digest", "5d0689ef49d2fae572b881b123a85ffa21595f36"},
+	{"abcdefghijklmnopqrstuvwxyz", "f71c27109c692c1b56bbdceb5b9d2865b3708dbc"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "12a053384a9c0c88e405a06c27dcf49ada62eb2b"},
+	{"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789", "b0e20b6e3116640286ed3a87a5713079b21f5189"},
+	{strings.Repeat("1234567890", 8), "9b752e45573d4b39f4dbd3323cab82bf63326bfb"},
+	{strings.Repeat("a", 1000000), "52783243c1697bdbe16d37f97f68f08325dc1528"},
+}
+
+func TestRipemd160(t *testing.T) {
+
+	for _, test := range hashTests {
+		x := Ripemd160([]byte(test.in))
+		if hex.EncodeToString(x[:]) != test.out {
+			fmt.Printf("%s (expected) %x (actual)\n", test.out, x)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestRipemd160Random(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+		data := make([]byte, rand.Int()&1023)
+		rand.Read(data)
+		x := Ripemd160(data)
+		h := ripemd160.New()
+		h.Write(data)
+		y := h.Sum(nil)
+		if !bytes.Equal(x[:], y) {
+			fmt.Printf("%x (expected) %x (actual)\n", y, x)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestRipemd160NoMutate(t *testing.T) {
+
+	for n := 0; n < 256; n++ {
+		buf := make([]byte, n+128)
+		rand.Read(buf)
+		orig := append([]byte{}, buf...)
+		data := buf[:n]
+
+		x := Ripemd160(data)
+		y := Ripemd160(append([]byte{}, data...))
+
+		if x != y {
+			t.Error("FAIL")
+		}
+		if !bytes.Equal(orig, buf) {
+			fmt.Printf("n %d: caller memory modified\n", n)
+			t.Error("FAIL")
+		}
+	}
+
+}
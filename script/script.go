@@ -0,0 +1,109 @@
+//-----------------------------------------------------------------------------
+/*
+
+Output Script (scriptPubKey) Classification
+
+https://en.bitcoin.it/wiki/Script
+https://developer.bitcoin.org/devguide/transactions.html#standard-transactions
+
+*/
+//-----------------------------------------------------------------------------
+
+package script
+
+import (
+	"encoding/binary"
+)
+
+//-----------------------------------------------------------------------------
+
+// opcodes
+const (
+	Op0           = 0x00
+	OpPushData1   = 0x4c
+	OpPushData2   = 0x4d
+	OpPushData4   = 0x4e
+	OpReturn      = 0x6a
+	OpDup         = 0x76
+	OpEqual       = 0x87
+	OpEqualVerify = 0x88
+	OpHash160     = 0xa9
+	OpCheckSig    = 0xac
+)
+
+//-----------------------------------------------------------------------------
+
+// Classify returns the kind of an output script and the data it contains.
+// The kinds are "p2pkh", "p2sh", "p2wpkh" and "p2wsh" with the hash, and
+// "op_return" with the script bytes after OP_RETURN. Any other script is
+// "nonstandard" with no data.
+func Classify(pkScript []byte) (kind string, data []byte) {
+	s := pkScript
+	n := len(s)
+	switch {
+	case n == 25 && s[0] == OpDup && s[1] == OpHash160 && s[2] == 20 && s[23] == OpEqualVerify && s[24] == OpCheckSig:
+		// OP_DUP OP_HASH160 <20 bytes> OP_EQUALVERIFY OP_CHECKSIG
+		return "p2pkh", s[3:23]
+	case n == 23 && s[0] == OpHash160 && s[1] == 20 && s[22] == OpEqual:
+		// OP_HASH160 <20 bytes> OP_EQUAL
+		return "p2sh", s[2:22]
+	case n == 22 && s[0] == Op0 && s[1] == 20:
+		// OP_0 <20 bytes>
+		return "p2wpkh", s[2:]
+	case n == 34 && s[0] == Op0 && s[1] == 32:
+		// OP_0 <32 bytes>
+		return "p2wsh", s[2:]
+	case n >= 1 && s[0] == OpReturn:
+		// OP_RETURN <data>
+		return "op_return", s[1:]
+	}
+	return "nonstandard", nil
+}
+
+//-----------------------------------------------------------------------------
+
+// push returns the data of a single push operation that makes up all of s.
+func push(s []byte) ([]byte, bool) {
+	if len(s) == 0 {
+		return nil, false
+	}
+	op := s[0]
+	s = s[1:]
+	var n uint64
+	switch {
+	case op == Op0:
+		n = 0
+	case op < OpPushData1:
+		n = uint64(op)
+	case op == OpPushData1 && len(s) >= 1:
+		n = uint64(s[0])
+		s = s[1:]
+	case op == OpPushData2 && len(s) >= 2:
+		n = uint64(binary.LittleEndian.Uint16(s))
+		s = s[2:]
+	case op == OpPushData4 && len(s) >= 4:
+		n = uint64(binary.LittleEndian.Uint32(s))
+		s = s[4:]
+	default:
+		return nil, false
+	}
+	if uint64(len(s)) != n {
+		return nil, false
+	}
+	return s, true
+}
+
+// ParseOpReturn returns the data pushed by an OP_RETURN output script.
+// A bare OP_RETURN has empty data. It returns false if the script isn't
+// OP_RETURN followed by at most a single push.
+func ParseOpReturn(pkScript []byte) ([]byte, bool) {
+	if len(pkScript) == 0 || pkScript[0] != OpReturn {
+		return nil, false
+	}
+	if len(pkScript) == 1 {
+		return []byte{}, true
+	}
+	return push(pkScript[1:])
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,76 @@
+package script
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+
+	tests := []struct {
+		script string
+		kind   string
+		data   string
+	}{
+		// genesis pubkey hash
+		{"76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac", "p2pkh", "62e907b15cbf27d5425399ebf6f0fb50ebb88f18"},
+		{"a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87", "p2sh", "b472a266d0bd89c13706a4132ccfb16f7c3b9fcb"},
+		{"0014751e76e8199196d454941c45d1b3a323f1433bd6", "p2wpkh", "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262", "p2wsh", "1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"6a0568656c6c6f", "op_return", "0568656c6c6f"},
+		{"6a", "op_return", ""},
+		// truncated p2pkh
+		{"76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888", "nonstandard", ""},
+		// p2pk (genesis coinbase output)
+		{"4104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac", "nonstandard", ""},
+		{"", "nonstandard", ""},
+	}
+
+	for _, test := range tests {
+		s, _ := hex.DecodeString(test.script)
+		kind, data := Classify(s)
+		if kind != test.kind || hex.EncodeToString(data) != test.data {
+			fmt.Printf("%s %s (expected) %s %x (actual)\n", test.kind, test.data, kind, data)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestParseOpReturn(t *testing.T) {
+
+	tests := []struct {
+		script string
+		data   string
+		ok     bool
+	}{
+		{"6a13636861726c6579206c6f766573206865696469", "636861726c6579206c6f766573206865696469", true}, // "charley loves heidi"
+		{"6a", "", true},
+		{"6a00", "", true},
+		{"6a4c0568656c6c6f", "68656c6c6f", true},
+		{"6a4d050068656c6c6f", "68656c6c6f", true},
+		{"6a4e0500000068656c6c6f", "68656c6c6f", true},
+		{"6a0568656c6c", "", false},     // truncated push
+		{"6a0568656c6c6f00", "", false}, // more than one push
+		{"6a4c", "", false},             // missing length
+		{"76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac", "", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		s, _ := hex.DecodeString(test.script)
+		data, ok := ParseOpReturn(s)
+		if ok != test.ok || hex.EncodeToString(data) != test.data {
+			fmt.Printf("%s %v (expected) %x %v (actual)\n", test.data, test.ok, data, ok)
+			t.Error("FAIL")
+		}
+	}
+
+	data, _ := hex.DecodeString("6a13636861726c6579206c6f766573206865696469")
+	msg, _ := ParseOpReturn(data)
+	if string(msg) != "charley loves heidi" {
+		t.Error("FAIL")
+	}
+
+}
@@ -0,0 +1,46 @@
+//-----------------------------------------------------------------------------
+/*
+
+SHA2-256 Batch Hashing
+
+*/
+//-----------------------------------------------------------------------------
+
+package sha2
+
+import (
+	"runtime"
+	"sync"
+)
+
+//-----------------------------------------------------------------------------
+
+// SumBatch returns the SHA256 hashes of the inputs, in input order.
+// The work is spread across GOMAXPROCS goroutines.
+func SumBatch(inputs [][]byte) [][Size256]byte {
+	out := make([][Size256]byte, len(inputs))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+
+	// each worker hashes a contiguous range of the inputs
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		lo := i * len(inputs) / workers
+		hi := (i + 1) * len(inputs) / workers
+		go func() {
+			defer wg.Done()
+			for j := lo; j < hi; j++ {
+				out[j] = Sha2_256(inputs[j])
+			}
+		}()
+	}
+	wg.Wait()
+
+	return out
+}
+
+//-----------------------------------------------------------------------------
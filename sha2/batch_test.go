@@ -0,0 +1,56 @@
+package sha2
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomInputs(n int) [][]byte {
+	inputs := make([][]byte, n)
+	for i := range inputs {
+		inputs[i] = make([]byte, rand.Int()&255)
+		rand.Read(inputs[i])
+	}
+	return inputs
+}
+
+func TestSumBatch(t *testing.T) {
+
+	inputs := randomInputs(1000)
+	// include empty inputs
+	inputs[0] = nil
+	inputs[1] = []byte{}
+
+	x := SumBatch(inputs)
+	if len(x) != len(inputs) {
+		t.Error("FAIL")
+	}
+	for i := range inputs {
+		if x[i] != Sha2_256(inputs[i]) {
+			t.Error("FAIL")
+		}
+	}
+
+	if len(SumBatch(nil)) != 0 {
+		t.Error("FAIL")
+	}
+
+}
+
+func BenchmarkSumBatch(b *testing.B) {
+	inputs := randomInputs(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumBatch(inputs)
+	}
+}
+
+func BenchmarkSumSerial(b *testing.B) {
+	inputs := randomInputs(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range inputs {
+			Sha2_256(inputs[j])
+		}
+	}
+}
@@ -0,0 +1,51 @@
+//-----------------------------------------------------------------------------
+/*
+
+SHA256 Block Function (amd64)
+
+Uses the SHA extensions (SHA-NI) when the CPU has them.
+
+https://www.intel.com/content/www/us/en/developer/articles/technical/intel-sha-extensions.html
+
+*/
+//-----------------------------------------------------------------------------
+
+package sha2
+
+//-----------------------------------------------------------------------------
+
+// cpuid returns the CPUID registers for a leaf and sub-leaf.
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// add512SHANI adds the whole 512 bit chunks of data to the hash state.
+//
+//go:noescape
+func add512SHANI(x *Hash256, data []byte)
+
+// hasSHANI returns true if the CPU has the SHA, SSSE3 and SSE4.1 extensions.
+func hasSHANI() bool {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if maxLeaf < 7 {
+		return false
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	_, ebx7, _, _ := cpuid(7, 0)
+	ssse3 := ecx1&(1<<9) != 0
+	sse41 := ecx1&(1<<19) != 0
+	sha := ebx7&(1<<29) != 0
+	return ssse3 && sse41 && sha
+}
+
+// useSHANI selects the SHA-NI block function.
+var useSHANI = hasSHANI()
+
+// add512 adds the whole 512 bit chunks of data to the hash state.
+func add512(x *Hash256, data []byte) {
+	if useSHANI {
+		add512SHANI(x, data)
+		return
+	}
+	add512Generic(x, data)
+}
+
+//-----------------------------------------------------------------------------
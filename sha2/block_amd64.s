@@ -0,0 +1,235 @@
+//-----------------------------------------------------------------------------
+/*
+
+SHA256 Block Function using the SHA extensions (SHA-NI)
+
+Based on the reference code in:
+S. Gulley, et al, "New Instructions Supporting the Secure Hash Algorithm on
+Intel Architecture Processors", July 2013
+
+Only SSE encoded instructions are used, so AVX is not needed.
+
+*/
+//-----------------------------------------------------------------------------
+
+#include "textflag.h"
+
+// register usage
+// DI: hash state pointer
+// SI: data pointer
+// DX: end of data pointer
+// AX: round constants pointer
+// X0: message + round constants (implicit SHA256RNDS2 operand)
+// X1: state ABEF
+// X2: state CDGH
+// X3..X6: message schedule
+// X7: temporary
+// X8: byte shuffle mask
+// X9, X10: saved state
+// X11: round constants
+
+// MSG_K adds the round constants for rounds 4*c..4*c+3 to X0.
+#define MSG_K(c) \
+	MOVOU (c*16)(AX), X11 \
+	PADDD X11, X0
+
+// ROUNDS4 does 4 rounds with the message + round constants in X0.
+#define ROUNDS4 \
+	SHA256RNDS2 X0, X1, X2 \
+	PSHUFD $0x0e, X0, X0 \
+	SHA256RNDS2 X0, X2, X1
+
+// LOAD loads and byte swaps 16 bytes of message at offset ofs into X0 and m.
+#define LOAD(ofs, m) \
+	MOVOU (ofs)(SI), X0 \
+	PSHUFB X8, X0 \
+	MOVO X0, m
+
+// SCHEDULE does 4 rounds and extends the message schedule.
+// m holds the message words for these rounds, the next words are built in t.
+#define SCHEDULE(c, m, a, t) \
+	MOVO m, X0 \
+	MSG_K(c) \
+	SHA256RNDS2 X0, X1, X2 \
+	MOVO m, X7 \
+	PALIGNR $4, a, X7 \
+	PADDD X7, t \
+	SHA256MSG2 m, t \
+	PSHUFD $0x0e, X0, X0 \
+	SHA256RNDS2 X0, X2, X1
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func add512SHANI(x *Hash256, data []byte)
+TEXT ·add512SHANI(SB), NOSPLIT, $0-32
+	MOVQ x+0(FP), DI
+	MOVQ data_base+8(FP), SI
+	MOVQ data_len+16(FP), DX
+	SHRQ $6, DX
+	SHLQ $6, DX
+	CMPQ DX, $0
+	JEQ done
+	ADDQ SI, DX
+
+	// load the state and reorder DCBA, HGFE -> ABEF, CDGH
+	MOVOU (DI), X1
+	MOVOU 16(DI), X2
+	PSHUFD $0xb1, X1, X1 // CDAB
+	PSHUFD $0x1b, X2, X2 // EFGH
+	MOVO X1, X7
+	PALIGNR $8, X2, X1   // ABEF
+	PBLENDW $0xf0, X7, X2 // CDGH
+
+	MOVOU flip_mask<>(SB), X8
+	LEAQ k256<>(SB), AX
+
+loop:
+	// save the state
+	MOVO X1, X9
+	MOVO X2, X10
+
+	// rounds 0-15
+	LOAD(0, X3)
+	MSG_K(0)
+	ROUNDS4
+	LOAD(16, X4)
+	MSG_K(1)
+	ROUNDS4
+	SHA256MSG1 X4, X3
+	LOAD(32, X5)
+	MSG_K(2)
+	ROUNDS4
+	SHA256MSG1 X5, X4
+	LOAD(48, X6)
+	SCHEDULE(3, X6, X5, X3)
+	SHA256MSG1 X6, X5
+
+	// rounds 16-51
+	SCHEDULE(4, X3, X6, X4)
+	SHA256MSG1 X3, X6
+	SCHEDULE(5, X4, X3, X5)
+	SHA256MSG1 X4, X3
+	SCHEDULE(6, X5, X4, X6)
+	SHA256MSG1 X5, X4
+	SCHEDULE(7, X6, X5, X3)
+	SHA256MSG1 X6, X5
+	SCHEDULE(8, X3, X6, X4)
+	SHA256MSG1 X3, X6
+	SCHEDULE(9, X4, X3, X5)
+	SHA256MSG1 X4, X3
+	SCHEDULE(10, X5, X4, X6)
+	SHA256MSG1 X5, X4
+	SCHEDULE(11, X6, X5, X3)
+	SHA256MSG1 X6, X5
+	SCHEDULE(12, X3, X6, X4)
+	SHA256MSG1 X3, X6
+
+	// rounds 52-59
+	SCHEDULE(13, X4, X3, X5)
+	SCHEDULE(14, X5, X4, X6)
+
+	// rounds 60-63
+	MOVO X6, X0
+	MSG_K(15)
+	ROUNDS4
+
+	// add the saved state
+	PADDD X9, X1
+	PADDD X10, X2
+
+	ADDQ $64, SI
+	CMPQ SI, DX
+	JNE loop
+
+	// write the state back in the ABCDEFGH order
+	PSHUFD $0x1b, X1, X1
+	PSHUFD $0xb1, X2, X2
+	MOVO X1, X7
+	PBLENDW $0xf0, X2, X1
+	PALIGNR $8, X7, X2
+	MOVOU X1, (DI)
+	MOVOU X2, 16(DI)
+
+done:
+	RET
+
+// shuffle mask to byte swap each 32 bit word
+DATA flip_mask<>+0(SB)/8, $0x0405060700010203
+DATA flip_mask<>+8(SB)/8, $0x0c0d0e0f08090a0b
+GLOBL flip_mask<>(SB), RODATA|NOPTR, $16
+
+// round constants
+DATA k256<>+0x00(SB)/4, $0x428a2f98
+DATA k256<>+0x04(SB)/4, $0x71374491
+DATA k256<>+0x08(SB)/4, $0xb5c0fbcf
+DATA k256<>+0x0c(SB)/4, $0xe9b5dba5
+DATA k256<>+0x10(SB)/4, $0x3956c25b
+DATA k256<>+0x14(SB)/4, $0x59f111f1
+DATA k256<>+0x18(SB)/4, $0x923f82a4
+DATA k256<>+0x1c(SB)/4, $0xab1c5ed5
+DATA k256<>+0x20(SB)/4, $0xd807aa98
+DATA k256<>+0x24(SB)/4, $0x12835b01
+DATA k256<>+0x28(SB)/4, $0x243185be
+DATA k256<>+0x2c(SB)/4, $0x550c7dc3
+DATA k256<>+0x30(SB)/4, $0x72be5d74
+DATA k256<>+0x34(SB)/4, $0x80deb1fe
+DATA k256<>+0x38(SB)/4, $0x9bdc06a7
+DATA k256<>+0x3c(SB)/4, $0xc19bf174
+DATA k256<>+0x40(SB)/4, $0xe49b69c1
+DATA k256<>+0x44(SB)/4, $0xefbe4786
+DATA k256<>+0x48(SB)/4, $0x0fc19dc6
+DATA k256<>+0x4c(SB)/4, $0x240ca1cc
+DATA k256<>+0x50(SB)/4, $0x2de92c6f
+DATA k256<>+0x54(SB)/4, $0x4a7484aa
+DATA k256<>+0x58(SB)/4, $0x5cb0a9dc
+DATA k256<>+0x5c(SB)/4, $0x76f988da
+DATA k256<>+0x60(SB)/4, $0x983e5152
+DATA k256<>+0x64(SB)/4, $0xa831c66d
+DATA k256<>+0x68(SB)/4, $0xb00327c8
+DATA k256<>+0x6c(SB)/4, $0xbf597fc7
+DATA k256<>+0x70(SB)/4, $0xc6e00bf3
+DATA k256<>+0x74(SB)/4, $0xd5a79147
+DATA k256<>+0x78(SB)/4, $0x06ca6351
+DATA k256<>+0x7c(SB)/4, $0x14292967
+DATA k256<>+0x80(SB)/4, $0x27b70a85
+DATA k256<>+0x84(SB)/4, $0x2e1b2138
+DATA k256<>+0x88(SB)/4, $0x4d2c6dfc
+DATA k256<>+0x8c(SB)/4, $0x53380d13
+DATA k256<>+0x90(SB)/4, $0x650a7354
+DATA k256<>+0x94(SB)/4, $0x766a0abb
+DATA k256<>+0x98(SB)/4, $0x81c2c92e
+DATA k256<>+0x9c(SB)/4, $0x92722c85
+DATA k256<>+0xa0(SB)/4, $0xa2bfe8a1
+DATA k256<>+0xa4(SB)/4, $0xa81a664b
+DATA k256<>+0xa8(SB)/4, $0xc24b8b70
+DATA k256<>+0xac(SB)/4, $0xc76c51a3
+DATA k256<>+0xb0(SB)/4, $0xd192e819
+DATA k256<>+0xb4(SB)/4, $0xd6990624
+DATA k256<>+0xb8(SB)/4, $0xf40e3585
+DATA k256<>+0xbc(SB)/4, $0x106aa070
+DATA k256<>+0xc0(SB)/4, $0x19a4c116
+DATA k256<>+0xc4(SB)/4, $0x1e376c08
+DATA k256<>+0xc8(SB)/4, $0x2748774c
+DATA k256<>+0xcc(SB)/4, $0x34b0bcb5
+DATA k256<>+0xd0(SB)/4, $0x391c0cb3
+DATA k256<>+0xd4(SB)/4, $0x4ed8aa4a
+DATA k256<>+0xd8(SB)/4, $0x5b9cca4f
+DATA k256<>+0xdc(SB)/4, $0x682e6ff3
+DATA k256<>+0xe0(SB)/4, $0x748f82ee
+DATA k256<>+0xe4(SB)/4, $0x78a5636f
+DATA k256<>+0xe8(SB)/4, $0x84c87814
+DATA k256<>+0xec(SB)/4, $0x8cc70208
+DATA k256<>+0xf0(SB)/4, $0x90befffa
+DATA k256<>+0xf4(SB)/4, $0xa4506ceb
+DATA k256<>+0xf8(SB)/4, $0xbef9a3f7
+DATA k256<>+0xfc(SB)/4, $0xc67178f2
+GLOBL k256<>(SB), RODATA|NOPTR, $256
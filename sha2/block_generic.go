@@ -0,0 +1,23 @@
+//go:build !amd64
+
+//-----------------------------------------------------------------------------
+/*
+
+SHA256 Block Function (generic)
+
+*/
+//-----------------------------------------------------------------------------
+
+package sha2
+
+//-----------------------------------------------------------------------------
+
+// useSHANI is always false without the amd64 SHA-NI code.
+var useSHANI = false
+
+// add512 adds the whole 512 bit chunks of data to the hash state.
+func add512(x *Hash256, data []byte) {
+	add512Generic(x, data)
+}
+
+//-----------------------------------------------------------------------------
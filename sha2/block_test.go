@@ -0,0 +1,65 @@
+package sha2
+
+import (
+	"crypto/sha256"
+	"math/rand"
+	"testing"
+)
+
+func TestAdd512(t *testing.T) {
+
+	if !useSHANI {
+		t.Log("SHA-NI is not available, only the generic code is tested")
+	}
+
+	for i := 0; i < 1000; i++ {
+
+		data := make([]byte, (rand.Int()&7)*64)
+		rand.Read(data)
+		var x Hash256
+		for j := range x {
+			x[j] = rand.Uint32()
+		}
+
+		y := x
+		add512Generic(&y, data)
+		z := x
+		add512(&z, data)
+		if y != z {
+			t.Error("FAIL")
+		}
+	}
+
+	// both paths give the standard hash
+	saved := useSHANI
+	defer func() { useSHANI = saved }()
+	for _, use := range []bool{false, saved} {
+		useSHANI = use
+		for i := 0; i < 100; i++ {
+			data := make([]byte, rand.Int()&1023)
+			rand.Read(data)
+			if Sha2_256(data) != sha256.Sum256(data) {
+				t.Error("FAIL")
+			}
+		}
+	}
+
+}
+
+func BenchmarkAdd512Generic(b *testing.B) {
+	x := hInit
+	data := make([]byte, 64)
+	b.SetBytes(64)
+	for i := 0; i < b.N; i++ {
+		add512Generic(&x, data)
+	}
+}
+
+func BenchmarkAdd512(b *testing.B) {
+	x := hInit
+	data := make([]byte, 64)
+	b.SetBytes(64)
+	for i := 0; i < b.N; i++ {
+		x.Add512(data)
+	}
+}
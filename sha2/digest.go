@@ -0,0 +1,109 @@
+//-----------------------------------------------------------------------------
+/*
+
+SHA2-256 Streaming Interface
+
+Implements hash.Hash for incremental hashing of data.
+
+*/
+//-----------------------------------------------------------------------------
+
+package sha2
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+	"os"
+)
+
+//-----------------------------------------------------------------------------
+
+// digest is a streaming SHA256 hash.
+type digest struct {
+	State
+}
+
+// New returns a hash.Hash computing the SHA256 checksum.
+func New() hash.Hash {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+func (d *digest) Size() int {
+	return Size256
+}
+
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	d.State.Write(p)
+	return len(p), nil
+}
+
+// Sum appends the current hash to b. The running state is not changed.
+func (d *digest) Sum(b []byte) []byte {
+	hash := d.State.Sum()
+	return append(b, hash[:]...)
+}
+
+// Sum256File returns the SHA256 hash of the contents of a file.
+func Sum256File(path string) ([Size256]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return [Size256]byte{}, err
+	}
+	defer f.Close()
+	d := new(digest)
+	d.Reset()
+	_, err = io.Copy(d, f)
+	if err != nil {
+		return [Size256]byte{}, err
+	}
+	return d.State.Sum(), nil
+}
+
+//-----------------------------------------------------------------------------
+
+// The marshalled state uses the same layout as crypto/sha256.
+const (
+	magic256      = "sha\x03"
+	marshaledSize = len(magic256) + 8*4 + BlockSize + 8
+)
+
+func (d *digest) MarshalBinary() ([]byte, error) {
+	b := make([]byte, marshaledSize)
+	k := copy(b, magic256)
+	for i := range d.h {
+		binary.BigEndian.PutUint32(b[k:], d.h[i])
+		k += 4
+	}
+	copy(b[k:], d.x[:d.nx])
+	k += BlockSize
+	binary.BigEndian.PutUint64(b[k:], d.len)
+	return b, nil
+}
+
+func (d *digest) UnmarshalBinary(b []byte) error {
+	if len(b) < len(magic256) || string(b[:len(magic256)]) != magic256 {
+		return errors.New("invalid hash state identifier")
+	}
+	if len(b) != marshaledSize {
+		return errors.New("invalid hash state size")
+	}
+	b = b[len(magic256):]
+	for i := range d.h {
+		d.h[i] = binary.BigEndian.Uint32(b)
+		b = b[4:]
+	}
+	b = b[copy(d.x[:], b):]
+	d.len = binary.BigEndian.Uint64(b)
+	d.nx = int(d.len % BlockSize)
+	return nil
+}
+
+//-----------------------------------------------------------------------------
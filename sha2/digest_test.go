@@ -0,0 +1,123 @@
+package sha2
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDigest(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+
+		d := New()
+		r := sha256.New()
+
+		// interleave writes of odd sizes
+		for j := 0; j < 8; j++ {
+			n := rand.Int() & 255
+			data := make([]byte, n)
+			rand.Read(data)
+			d.Write(data)
+			r.Write(data)
+
+			// sum should not disturb the running state
+			x := d.Sum(nil)
+			y := r.Sum(nil)
+			if !bytes.Equal(x, y) {
+				t.Error("FAIL")
+			}
+		}
+	}
+
+}
+
+func TestDigestReset(t *testing.T) {
+
+	data := make([]byte, 100)
+	rand.Read(data)
+
+	d := New()
+	d.Write([]byte("junk"))
+	d.Reset()
+	d.Write(data)
+
+	x := d.Sum(nil)
+	y := sha256.Sum256(data)
+	if !bytes.Equal(x, y[:]) {
+		t.Error("FAIL")
+	}
+
+	if d.Size() != Size256 || d.BlockSize() != BlockSize {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestDigestMarshal(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+
+		data := make([]byte, rand.Int()&1023)
+		rand.Read(data)
+		k := rand.Intn(len(data) + 1)
+
+		// hash half the stream
+		d0 := New()
+		d0.Write(data[:k])
+		state, err := d0.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Error("FAIL")
+		}
+
+		// resume in a fresh hasher
+		d1 := New()
+		err = d1.(encoding.BinaryUnmarshaler).UnmarshalBinary(state)
+		if err != nil {
+			t.Error("FAIL")
+		}
+		d1.Write(data[k:])
+
+		x := d1.Sum(nil)
+		y := sha256.Sum256(data)
+		if !bytes.Equal(x, y[:]) {
+			t.Error("FAIL")
+		}
+	}
+
+	d := New()
+	err := d.(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte("sha\x03"))
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestSum256File(t *testing.T) {
+
+	dir := t.TempDir()
+
+	for _, n := range []int{0, 1, 64, 1000, 100000} {
+		data := make([]byte, n)
+		rand.Read(data)
+		path := filepath.Join(dir, "data")
+		err := os.WriteFile(path, data, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := Sum256File(path)
+		if err != nil || x != sha256.Sum256(data) {
+			t.Error("FAIL")
+		}
+	}
+
+	_, err := Sum256File(filepath.Join(dir, "missing"))
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
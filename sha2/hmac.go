@@ -0,0 +1,45 @@
+//-----------------------------------------------------------------------------
+/*
+
+HMAC-SHA256
+
+https://en.wikipedia.org/wiki/HMAC
+
+*/
+//-----------------------------------------------------------------------------
+
+package sha2
+
+//-----------------------------------------------------------------------------
+
+// HMAC returns the HMAC-SHA256 of a message using the key.
+func HMAC(key, message []byte) [Size256]byte {
+
+	// keys longer than the block size are hashed
+	if len(key) > BlockSize {
+		k := Sha2_256(key)
+		key = k[:]
+	}
+
+	var ipad, opad [BlockSize]byte
+	copy(ipad[:], key)
+	copy(opad[:], key)
+	for i := range ipad {
+		ipad[i] ^= 0x36
+		opad[i] ^= 0x5c
+	}
+
+	// inner hash
+	s := NewState()
+	s.Write(ipad[:])
+	s.Write(message)
+	inner := s.Sum()
+
+	// outer hash
+	s.Reset()
+	s.Write(opad[:])
+	s.Write(inner[:])
+	return s.Sum()
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,32 @@
+package sha2
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/rand"
+	"testing"
+)
+
+func TestHMAC(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+
+		// include keys longer than the block size
+		key := make([]byte, rand.Int()&127)
+		rand.Read(key)
+		msg := make([]byte, rand.Int()&1023)
+		rand.Read(msg)
+
+		x := HMAC(key, msg)
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write(msg)
+		y := mac.Sum(nil)
+
+		if !bytes.Equal(x[:], y) {
+			t.Error("FAIL")
+		}
+	}
+
+}
@@ -1,7 +1,7 @@
 //-----------------------------------------------------------------------------
 /*
 
-SHA2-256 Implementation
+SHA2-256/224 Implementation
 
 https://en.wikipedia.org/wiki/SHA-2
 
@@ -11,6 +11,8 @@ https://en.wikipedia.org/wiki/SHA-2
 package sha2
 
 import (
+	"crypto/subtle"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"math/bits"
@@ -21,6 +23,7 @@ import (
 //-----------------------------------------------------------------------------
 
 const Size256 = 32
+const Size224 = 28
 
 type Hash256 [8]uint32
 
@@ -30,6 +33,16 @@ func (h *Hash256) Bytes() [Size256]byte {
 	return out
 }
 
+// BytesReversed returns the hash bytes reversed from Bytes.
+// Note that Bytes (internal order) is what is serialized on the wire for
+// block headers and txids. The reversed order is the one shown by the display
+// hex (String), i.e. the proof of work integer in big-endian byte order.
+func (h *Hash256) BytesReversed() [Size256]byte {
+	b := h.Bytes()
+	util.ReverseInPlace(b[:])
+	return b
+}
+
 func (h *Hash256) Copy(dst []byte) {
 	if len(dst) != Size256 {
 		panic("len(dst) != Size256")
@@ -39,25 +52,128 @@ func (h *Hash256) Copy(dst []byte) {
 	copy(dst, src[:])
 }
 
-func FromString(s string) (Hash256, error) {
+// CopyChecked copies the hash bytes (in internal order) to dst.
+// It returns an error rather than panicking if dst is not Size256 bytes.
+func (h *Hash256) CopyChecked(dst []byte) error {
+	if len(dst) != Size256 {
+		return errors.New("slice is not 32 bytes")
+	}
+	util.Conv32to8(dst, h[:])
+	return nil
+}
+
+// Clone returns a copy of the hash (or hash state).
+// The copy does not alias h, so it is safe to modify either one.
+func (h *Hash256) Clone() Hash256 {
+	return *h
+}
+
+// Equal returns true if the hashes are equal.
+func (h *Hash256) Equal(other *Hash256) bool {
+	return *h == *other
+}
+
+// EqualConstantTime returns true if the hashes are equal.
+// The comparison time is independent of the hash values.
+func (h *Hash256) EqualConstantTime(other *Hash256) bool {
+	var v uint32
+	for i := range h {
+		v |= h[i] ^ other[i]
+	}
+	return subtle.ConstantTimeEq(int32(v), 0) == 1
+}
+
+// IsZero returns true if all the hash words are zero.
+func (h *Hash256) IsZero() bool {
+	return *h == Hash256{}
+}
+
+// LeadingZeroBits returns the number of leading zero bits of the hash.
+// The hash is taken as the little-endian integer used for proof of work, so
+// this counts the leading zeroes of the display order (String) hex.
+func LeadingZeroBits(h *Hash256) int {
+	n := 0
+	for i := len(h) - 1; i >= 0; i-- {
+		z := bits.LeadingZeros32(bits.ReverseBytes32(h[i]))
+		n += z
+		if z != 32 {
+			break
+		}
+	}
+	return n
+}
+
+// String returns the hash as hex in display order.
+// The bytes are reversed, matching block explorers and RPC output.
+func (h *Hash256) String() string {
+	b := h.BytesReversed()
+	return hex.EncodeToString(b[:])
+}
+
+// StringInternal returns the hash as hex in internal byte order.
+func (h *Hash256) StringInternal() string {
+	b := h.Bytes()
+	return hex.EncodeToString(b[:])
+}
+
+// MarshalText returns the hash as hex in display order (encoding.TextMarshaler).
+func (h *Hash256) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText parses hex in display order (encoding.TextUnmarshaler).
+func (h *Hash256) UnmarshalText(text []byte) error {
+	if len(text) != 2*Size256 {
+		return errors.New("text is not 64 hex characters")
+	}
+	x, err := FromStringReversed(string(text))
+	if err != nil {
+		return err
+	}
+	*h = x
+	return nil
+}
+
+// FromBytes returns the hash for 32 bytes in internal byte order (the inverse of Bytes).
+func FromBytes(b []byte) (Hash256, error) {
 	var out Hash256
+	if len(b) != Size256 {
+		return out, errors.New("slice is not 32 bytes")
+	}
+	util.Conv8to32(out[:], b)
+	return out, nil
+}
+
+// FromString parses hex in internal byte order (the inverse of StringInternal).
+func FromString(s string) (Hash256, error) {
 	x, err := hex.DecodeString(s)
 	if err != nil {
-		return out, err
+		return Hash256{}, err
 	}
 	if len(x) != Size256 {
-		return out, errors.New("string is not 32 bytes")
+		return Hash256{}, errors.New("string is not 32 bytes")
 	}
-	util.Conv8to32(out[:], x)
-	return out, nil
+	return FromBytes(x)
 }
 
-//-----------------------------------------------------------------------------
+// FromStringReversed parses hex in display order (the inverse of String).
+func FromStringReversed(s string) (Hash256, error) {
+	x, err := hex.DecodeString(s)
+	if err != nil {
+		return Hash256{}, err
+	}
+	if len(x) != Size256 {
+		return Hash256{}, errors.New("string is not 32 bytes")
+	}
+	util.ReverseInPlace(x)
+	return FromBytes(x)
+}
 
-// pad512 pads a slice to a multiple of 512 bits (64 bytes)
-func pad512(data []byte) []byte {
+//-----------------------------------------------------------------------------
 
-	n := uint64(len(data))
+// padLen returns the padding length for an n byte message.
+// The padding is 0x80, zeroes, and the 64 bit message length.
+func padLen(n uint64) uint64 {
 
 	pad := 64 - (n % 64)
 
@@ -65,21 +181,62 @@ func pad512(data []byte) []byte {
 		pad += 64
 	}
 
-	data = append(data, make([]byte, pad)...)
+	return pad
+}
+
+// PaddedBlocks returns the number of 512 bit blocks for a message of length
+// bytes after padding, e.g. 2 for an 80 byte block header.
+func PaddedBlocks(length int) int {
+	if length < 0 {
+		panic("length < 0")
+	}
+	n := uint64(length)
+	return int((n + padLen(n)) / 64)
+}
+
+// writePad writes the padding for an n byte message into buf[n:n+padLen(n)].
+func writePad(buf []byte, n uint64) {
+
+	pad := padLen(n)
 
-	data[n] = 0x80
+	buf[n] = 0x80
 	end := n + pad - 1
+	for i := n + 1; i < end-7; i++ {
+		buf[i] = 0
+	}
 	n *= 8
 
-	data[end-7] = uint8(n >> 56)
-	data[end-6] = uint8(n >> 48)
-	data[end-5] = uint8(n >> 40)
-	data[end-4] = uint8(n >> 32)
-	data[end-3] = uint8(n >> 24)
-	data[end-2] = uint8(n >> 16)
-	data[end-1] = uint8(n >> 8)
-	data[end-0] = uint8(n >> 0)
+	buf[end-7] = uint8(n >> 56)
+	buf[end-6] = uint8(n >> 48)
+	buf[end-5] = uint8(n >> 40)
+	buf[end-4] = uint8(n >> 32)
+	buf[end-3] = uint8(n >> 24)
+	buf[end-2] = uint8(n >> 16)
+	buf[end-1] = uint8(n >> 8)
+	buf[end-0] = uint8(n >> 0)
+}
+
+// pad512 pads a slice to a multiple of 512 bits (64 bytes)
+// The padded message is a new slice, the input is not modified.
+func pad512(data []byte) []byte {
+	n := uint64(len(data))
+	buf := make([]byte, n+padLen(n))
+	copy(buf, data)
+	writePad(buf, n)
+	return buf
+}
 
+// pad512InPlace pads a slice to a multiple of 512 bits (64 bytes)
+// The padding is written to data[len:cap] if the slice has the capacity,
+// otherwise the padded message is a new slice.
+func pad512InPlace(data []byte) []byte {
+	n := uint64(len(data))
+	total := n + padLen(n)
+	if uint64(cap(data)) < total {
+		return pad512(data)
+	}
+	data = data[:total]
+	writePad(data, n)
 	return data
 }
 
@@ -89,6 +246,16 @@ var hInit = Hash256{
 	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
 }
 
+var hInit224 = Hash256{
+	0xc1059ed8, 0x367cd507, 0x3070dd17, 0xf70e5939, 0xffc00b31, 0x68581511, 0x64f98fa7, 0xbefa4fa4,
+}
+
+// Init256 returns the initial SHA256 hash state.
+// Use it with Add512 and Clone to precompute a midstate.
+func Init256() Hash256 {
+	return hInit
+}
+
 var k = [64]uint32{
 	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
 	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
@@ -100,75 +267,178 @@ var k = [64]uint32{
 	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
 }
 
+// Add512 adds a 512 bit chunk of data to the hash state.
 func (x *Hash256) Add512(data []byte) {
+	_ = data[63] // bounds check
+	add512(x, data[:64])
+}
 
-	// create a 64-entry message schedule array w[0..63] of 32-bit words
-	var w [64]uint32
-
-	// copy chunk into first 16 words w[0..15] of the message schedule array
-	for i := 0; i < 16; i++ {
-		j := i * 4
-		w[i] = (uint32(data[j]) << 24) |
-			(uint32(data[j+1]) << 16) |
-			(uint32(data[j+2]) << 8) |
-			uint32(data[j+3])
+// add512Generic adds the whole 512 bit chunks of data to the hash state.
+func add512Generic(x *Hash256, data []byte) {
+
+	for ; len(data) >= 64; data = data[64:] {
+
+		// create a 64-entry message schedule array w[0..63] of 32-bit words
+		var w [64]uint32
+
+		// copy chunk into first 16 words w[0..15] of the message schedule array
+		for i := 0; i < 16; i++ {
+			j := i * 4
+			w[i] = (uint32(data[j]) << 24) |
+				(uint32(data[j+1]) << 16) |
+				(uint32(data[j+2]) << 8) |
+				uint32(data[j+3])
+		}
+
+		for i := 16; i < 64; i++ {
+			v0 := w[i-15]
+			s0 := bits.RotateLeft32(v0, -7) ^ bits.RotateLeft32(v0, -18) ^ (v0 >> 3)
+			v1 := w[i-2]
+			s1 := bits.RotateLeft32(v1, -17) ^ bits.RotateLeft32(v1, -19) ^ (v1 >> 10)
+			w[i] = w[i-16] + s0 + w[i-7] + s1
+		}
+
+		// Initialize working variables to current hash value
+		a, b, c, d, e, f, g, h := x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7]
+
+		// Compression function main loop
+		for i := 0; i < 64; i++ {
+
+			s1 := bits.RotateLeft32(e, -6) ^ bits.RotateLeft32(e, -11) ^ bits.RotateLeft32(e, -25)
+			ch := (e & f) ^ ((^e) & g)
+			tmp1 := h + s1 + ch + k[i] + w[i]
+			s0 := bits.RotateLeft32(a, -2) ^ bits.RotateLeft32(a, -13) ^ bits.RotateLeft32(a, -22)
+			maj := (a & b) ^ (a & c) ^ (b & c)
+			tmp2 := s0 + maj
+
+			h = g
+			g = f
+			f = e
+			e = d + tmp1
+			d = c
+			c = b
+			b = a
+			a = tmp1 + tmp2
+		}
+
+		// Add the compressed chunk to the current hash value
+		x[0] += a
+		x[1] += b
+		x[2] += c
+		x[3] += d
+		x[4] += e
+		x[5] += f
+		x[6] += g
+		x[7] += h
 	}
+}
 
-	for i := 16; i < 64; i++ {
-		v0 := w[i-15]
-		s0 := bits.RotateLeft32(v0, -7) ^ bits.RotateLeft32(v0, -18) ^ (v0 >> 3)
-		v1 := w[i-2]
-		s1 := bits.RotateLeft32(v1, -17) ^ bits.RotateLeft32(v1, -19) ^ (v1 >> 10)
-		w[i] = w[i-16] + s0 + w[i-7] + s1
+// add hashes a padded buffer that is a multiple of 512 bits
+func (x *Hash256) add(data []byte) {
+	add512(x, data)
+}
+
+// sum adds an unpadded message to the hash state.
+// The whole 512 bit chunks are hashed directly from data and the tail is
+// padded in a local buffer, so data is not modified and nothing is allocated.
+func (x *Hash256) sum(data []byte) {
+	n := len(data) &^ 63
+	if n > 0 {
+		add512(x, data[:n])
 	}
+	var tail [128]byte
+	k := uint64(copy(tail[:], data[n:]))
+	writePad(tail[:], k)
+	// the length field is for the whole message, not just the tail
+	end := k + padLen(k)
+	binary.BigEndian.PutUint64(tail[end-8:end], uint64(len(data))*8)
+	add512(x, tail[:end])
+}
 
-	// Initialize working variables to current hash value
-	a, b, c, d, e, f, g, h := x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7]
+func Sha2_256(data []byte) [Size256]byte {
 
-	// Compression function main loop
-	for i := 0; i < 64; i++ {
+	x := hInit
+	x.sum(data)
+	return x.Bytes()
+}
 
-		s1 := bits.RotateLeft32(e, -6) ^ bits.RotateLeft32(e, -11) ^ bits.RotateLeft32(e, -25)
-		ch := (e & f) ^ ((^e) & g)
-		tmp1 := h + s1 + ch + k[i] + w[i]
-		s0 := bits.RotateLeft32(a, -2) ^ bits.RotateLeft32(a, -13) ^ bits.RotateLeft32(a, -22)
-		maj := (a & b) ^ (a & c) ^ (b & c)
-		tmp2 := s0 + maj
+//-----------------------------------------------------------------------------
 
-		h = g
-		g = f
-		f = e
-		e = d + tmp1
-		d = c
-		c = b
-		b = a
-		a = tmp1 + tmp2
-	}
+// Sum256Hash returns the SHA256 hash of the data as a Hash256.
+func Sum256Hash(data []byte) Hash256 {
+	x := hInit
+	x.sum(data)
+	return x
+}
 
-	// Add the compressed chunk to the current hash value
-	x[0] += a
-	x[1] += b
-	x[2] += c
-	x[3] += d
-	x[4] += e
-	x[5] += f
-	x[6] += g
-	x[7] += h
+// Sum256String returns the SHA256 hash of a string.
+func Sum256String(s string) [Size256]byte {
+	return Sha2_256([]byte(s))
 }
 
-func Sha2_256(data []byte) [Size256]byte {
+// Sha2_224 returns the SHA224 hash, a truncated SHA256 with a different initial state.
+func Sha2_224(data []byte) [Size224]byte {
+	x := hInit224
+	x.sum(data)
+	b := x.Bytes()
+	var out [Size224]byte
+	copy(out[:], b[:Size224])
+	return out
+}
 
-	// pad to a multiple of 512 bits
-	data = pad512(data)
+// Sha2_256d returns the double SHA256 hash, SHA256(SHA256(data)).
+func Sha2_256d(data []byte) [Size256]byte {
 
 	x := hInit
+	x.sum(data)
+
+	// the second pass is a single 512 bit chunk
+	var buf [64]byte
+	x.Copy(buf[:Size256])
+	buf[Size256] = 0x80
+	buf[62] = (Size256 * 8) >> 8
+	buf[63] = (Size256 * 8) & 0xff
 
-	// for each 512 bit chunk
-	for i := 0; i < len(data)/64; i++ {
-		j := i * 64
-		x.Add512(data[j : j+64])
+	x = hInit
+	x.Add512(buf[:])
+
+	return x.Bytes()
+}
+
+//-----------------------------------------------------------------------------
+
+// Sum256dVerbose returns both stages of the double SHA256 hash.
+// first is the SHA256 of the data and second is the SHA256 of first.
+func Sum256dVerbose(data []byte) (first, second [Size256]byte) {
+	first = Sha2_256(data)
+	second = Sha2_256(first[:])
+	return
+}
+
+// Sum256Into writes the SHA256 hash of data to dst.
+// The scratch buffer is used for the padded message, so it must have a length
+// of at least len(data) rounded up to the padded length. Reusing the scratch
+// buffer avoids allocation in tight loops.
+func Sum256Into(dst *[Size256]byte, scratch []byte, data []byte) {
+	n := uint64(len(data))
+	total := n + padLen(n)
+	if uint64(len(scratch)) < total {
+		panic("len(scratch) < padded length")
 	}
+	copy(scratch, data)
+	writePad(scratch, n)
+	x := hInit
+	x.add(scratch[:total])
+	x.Copy(dst[:])
+}
 
+// Sum256InPlace returns the SHA256 hash of the data.
+// The spare capacity data[len:cap] is used as scratch for the padding, so
+// the caller must own it (e.g. an 80 byte header in a 128 byte buffer).
+// With enough capacity nothing is allocated.
+func Sum256InPlace(data []byte) [Size256]byte {
+	x := hInit
+	x.add(pad512InPlace(data))
 	return x.Bytes()
 }
 
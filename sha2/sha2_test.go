@@ -3,6 +3,8 @@ package sha2
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"math/rand"
 	"testing"
 )
@@ -24,3 +26,529 @@ func TestSha2_256(t *testing.T) {
 	}
 
 }
+
+func TestSha2_256Boundaries(t *testing.T) {
+
+	// n bytes of "a", at the one/two/three block padding boundaries
+	tests := []struct {
+		n      int
+		digest string
+	}{
+		{0, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{55, "9f4390f8d30c2dd92ec9f095b65e2b9ae9b0a925a5258e241c9f1e910f734318"},
+		{56, "b35439a4ac6f0948b6d6f9e3c6af0f5f590ce20f1bde7090ef7970686ec6738a"},
+		{63, "7d3e74a05d7db15bce4ad9ec0658ea98e3f06eeecf16b4c6fff2da457ddc2f34"},
+		{64, "ffe054fe7ae0cb6dc65c3af9b61d5209f439851db43d0ba5997337df154668eb"},
+		{119, "31eba51c313a5c08226adf18d4a359cfdfd8d2e816b13f4af952f7ea6584dcfb"},
+		{120, "2f3d335432c70b580af0e8e1b3674a7c020d683aa5f73aaaedfdc55af904c21c"},
+	}
+
+	for _, test := range tests {
+		x := Sha2_256(bytes.Repeat([]byte("a"), test.n))
+		s := hex.EncodeToString(x[:])
+		if s != test.digest {
+			fmt.Printf("%d: %s (expected) %s (actual)\n", test.n, test.digest, s)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestSha2_256d(t *testing.T) {
+
+	for i := 0; i < 10000; i++ {
+
+		n := rand.Int() & ((1 << 12) - 1)
+		data := make([]byte, n)
+		rand.Read(data)
+
+		x := Sha2_256d(data)
+		y0 := sha256.Sum256(data)
+		y1 := sha256.Sum256(y0[:])
+
+		if !bytes.Equal(x[:], y1[:]) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestMidstate(t *testing.T) {
+
+	for i := 0; i < 100; i++ {
+
+		data := make([]byte, 128)
+		rand.Read(data)
+		y := sha256.Sum256(data)
+
+		mid := Init256()
+		mid.Add512(data[:64])
+
+		// the padding block for a 128 byte message
+		tail := pad512(make([]byte, 128))[128:]
+
+		x := mid.Clone()
+		x.Add512(data[64:])
+		x.Add512(tail)
+
+		b := x.Bytes()
+		if !bytes.Equal(b[:], y[:]) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestSha2_224(t *testing.T) {
+
+	for i := 0; i < 10000; i++ {
+
+		n := rand.Int() & ((1 << 12) - 1)
+		data := make([]byte, n)
+		rand.Read(data)
+
+		x := Sha2_224(data)
+		y := sha256.Sum224(data)
+
+		if !bytes.Equal(x[:], y[:]) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestString(t *testing.T) {
+
+	// block 125552
+	internal := "1dbd981fe6985776b644b173a4d0385ddc1aa2a829688d1e0000000000000000"
+	display := "00000000000000001e8d6829a8a21adc5d38d0a473b144b6765798e61f98bd1d"
+
+	h, err := FromString(internal)
+	if err != nil {
+		t.Error("FAIL")
+	}
+	if h.String() != display {
+		fmt.Printf("%s (expected) %s (actual)\n", display, h.String())
+		t.Error("FAIL")
+	}
+	if h.StringInternal() != internal {
+		fmt.Printf("%s (expected) %s (actual)\n", internal, h.StringInternal())
+		t.Error("FAIL")
+	}
+
+}
+
+func TestEqual(t *testing.T) {
+
+	for i := 0; i < 10000; i++ {
+
+		var a, b Hash256
+		for j := range a {
+			a[j] = rand.Uint32()
+		}
+		b = a
+		// sometimes change one word
+		if rand.Int()&1 == 1 {
+			b[rand.Int()&7] ^= 1 << (rand.Int() & 31)
+		}
+
+		x := a.Equal(&b)
+		y := a.EqualConstantTime(&b)
+		if x != y || x != (a == b) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+// benchEqualConstantTime compares a hash with a copy that differs in one word.
+// The time should be the same whichever word differs (or none).
+func benchEqualConstantTime(b *testing.B, word int) {
+	var x, y Hash256
+	for i := range x {
+		x[i] = rand.Uint32()
+	}
+	y = x
+	if word >= 0 {
+		y[word] ^= 1
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		x.EqualConstantTime(&y)
+	}
+}
+
+func BenchmarkEqualConstantTimeMatch(b *testing.B) {
+	benchEqualConstantTime(b, -1)
+}
+
+func BenchmarkEqualConstantTimeFirst(b *testing.B) {
+	benchEqualConstantTime(b, 0)
+}
+
+func BenchmarkEqualConstantTimeLast(b *testing.B) {
+	benchEqualConstantTime(b, 7)
+}
+
+func TestFromStringReversed(t *testing.T) {
+
+	// block 125552
+	display := "00000000000000001e8d6829a8a21adc5d38d0a473b144b6765798e61f98bd1d"
+
+	h, err := FromStringReversed(display)
+	if err != nil {
+		t.Error("FAIL")
+	}
+	if h.String() != display {
+		fmt.Printf("%s (expected) %s (actual)\n", display, h.String())
+		t.Error("FAIL")
+	}
+
+	_, err = FromStringReversed(display[2:])
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestSum256Into(t *testing.T) {
+
+	var x [Size256]byte
+	scratch := make([]byte, 1<<12+128)
+
+	for i := 0; i < 10000; i++ {
+
+		n := rand.Int() & ((1 << 12) - 1)
+		data := make([]byte, n)
+		rand.Read(data)
+
+		Sum256Into(&x, scratch, data)
+		y := sha256.Sum256(data)
+
+		if !bytes.Equal(x[:], y[:]) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func BenchmarkSha2_256(b *testing.B) {
+	data := make([]byte, 80)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Sha2_256(data)
+	}
+}
+
+func BenchmarkSum256Into(b *testing.B) {
+	data := make([]byte, 80)
+	scratch := make([]byte, 128)
+	var x [Size256]byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Sum256Into(&x, scratch, data)
+	}
+}
+
+func TestPad512(t *testing.T) {
+
+	for n := 0; n < 256; n++ {
+		data := make([]byte, n, n+128)
+		rand.Read(data)
+		// dirty the spare capacity
+		rand.Read(data[n:cap(data)])
+		spare := append([]byte{}, data[n:cap(data)]...)
+
+		x := pad512(data)
+		y := pad512(append([]byte{}, data...))
+
+		if !bytes.Equal(x, y) || len(x)%64 != 0 {
+			t.Error("FAIL")
+		}
+		if !bytes.Equal(spare, data[n:cap(data)]) {
+			// should not write past len(data)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestSumNoMutate(t *testing.T) {
+
+	for n := 0; n < 256; n++ {
+		buf := make([]byte, n+128)
+		rand.Read(buf)
+		orig := append([]byte{}, buf...)
+		data := buf[:n]
+
+		x := Sha2_256(data)
+		y := Sha2_256(append([]byte{}, data...))
+		Sha2_256d(data)
+		Sum256Hash(data)
+		Sha2_224(data)
+
+		if x != y {
+			t.Error("FAIL")
+		}
+		if !bytes.Equal(orig, buf) {
+			fmt.Printf("n %d: caller memory modified\n", n)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func BenchmarkPad512(b *testing.B) {
+	data := make([]byte, 80)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pad512(data)
+	}
+}
+
+func TestSum256InPlace(t *testing.T) {
+
+	for n := 0; n < 256; n++ {
+		// with and without spare capacity
+		for _, extra := range []int{0, 128} {
+			data := make([]byte, n, n+extra)
+			rand.Read(data)
+			rand.Read(data[n:cap(data)])
+			expected := Sha2_256(data)
+			x := Sum256InPlace(data)
+			if x != expected {
+				fmt.Printf("%x (expected) %x (actual)\n", expected, x)
+				t.Error("FAIL")
+			}
+			if extra != 0 && data[:n+1][n] != 0x80 {
+				// should have padded in place
+				t.Error("FAIL")
+			}
+		}
+	}
+
+}
+
+func BenchmarkPad512InPlace(b *testing.B) {
+	data := make([]byte, 80)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pad512InPlace(data)
+	}
+}
+
+func BenchmarkPad512InPlaceCapacity(b *testing.B) {
+	data := make([]byte, 80, 128)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pad512InPlace(data)
+	}
+}
+
+func TestSum256String(t *testing.T) {
+
+	x := Sum256String("abc")
+	expected := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if hex.EncodeToString(x[:]) != expected {
+		fmt.Printf("%s (expected) %x (actual)\n", expected, x)
+		t.Error("FAIL")
+	}
+
+}
+
+func TestIsZero(t *testing.T) {
+
+	var h Hash256
+	if !h.IsZero() {
+		t.Error("FAIL")
+	}
+
+	h[7] = 1
+	if h.IsZero() {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestFromBytes(t *testing.T) {
+
+	for i := 0; i < 100; i++ {
+		var h Hash256
+		for j := range h {
+			h[j] = rand.Uint32()
+		}
+		b := h.Bytes()
+		x, err := FromBytes(b[:])
+		if err != nil || x != h {
+			t.Error("FAIL")
+		}
+	}
+
+	_, err := FromBytes(make([]byte, 31))
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestClone(t *testing.T) {
+
+	h := Init256()
+	p := &h
+	x := p.Clone()
+
+	x[0] ^= 1
+	if h == x || h != Init256() {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+
+	tests := []struct {
+		s string
+		n int
+	}{
+		{"0000abcd00000000000000000000000000000000000000000000000000000000", 16},
+		{"00000000000000001e8d6829a8a21adc5d38d0a473b144b6765798e61f98bd1d", 67}, // block 125552
+		{"8000000000000000000000000000000000000000000000000000000000000000", 0},
+		{"0000000000000000000000000000000000000000000000000000000000000001", 255},
+		{"0000000000000000000000000000000000000000000000000000000000000000", 256},
+	}
+
+	for _, test := range tests {
+		h, _ := FromStringReversed(test.s)
+		n := LeadingZeroBits(&h)
+		if n != test.n {
+			fmt.Printf("%d (expected) %d (actual)\n", test.n, n)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestCopyChecked(t *testing.T) {
+
+	h, _ := FromStringReversed("00000000000000001e8d6829a8a21adc5d38d0a473b144b6765798e61f98bd1d")
+
+	buf := make([]byte, Size256)
+	if h.CopyChecked(buf) != nil {
+		t.Error("FAIL")
+	}
+	b := h.Bytes()
+	if !bytes.Equal(buf, b[:]) {
+		t.Error("FAIL")
+	}
+
+	// wrong lengths return an error
+	for _, n := range []int{0, 31, 33} {
+		if h.CopyChecked(make([]byte, n)) == nil {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestBytesReversed(t *testing.T) {
+
+	for i := 0; i < 100; i++ {
+		var h Hash256
+		for j := range h {
+			h[j] = rand.Uint32()
+		}
+		x := h.Bytes()
+		y := h.BytesReversed()
+		for j := range y {
+			if x[j] != y[Size256-1-j] {
+				t.Error("FAIL")
+				break
+			}
+		}
+		if hex.EncodeToString(y[:]) != h.String() {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestMarshalText(t *testing.T) {
+
+	// block 125552 merkle root
+	s := "2b12fcf1b09288fcaff797d71e950e71ae42b91e8bdb2304758dfcffc2b620e3"
+
+	var h Hash256
+	err := h.UnmarshalText([]byte(s))
+	if err != nil || h.String() != s {
+		t.Error("FAIL")
+	}
+	x, err := h.MarshalText()
+	if err != nil || string(x) != s {
+		fmt.Printf("%s (expected) %s (actual)\n", s, x)
+		t.Error("FAIL")
+	}
+
+	bad := []string{
+		"",
+		s[:62],
+		s + "00",
+		"x" + s[1:],
+	}
+	for _, b := range bad {
+		if h.UnmarshalText([]byte(b)) == nil {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestSum256Hash(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+		data := make([]byte, rand.Int()&255)
+		rand.Read(data)
+		h := Sum256Hash(data)
+		if h.Bytes() != Sha2_256(data) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestPaddedBlocks(t *testing.T) {
+
+	tests := []struct {
+		n      int
+		blocks int
+	}{
+		{0, 1}, {55, 1}, {56, 2}, {63, 2}, {64, 2}, {80, 2}, {119, 2}, {120, 3},
+	}
+
+	for _, test := range tests {
+		x := PaddedBlocks(test.n)
+		if x != test.blocks {
+			fmt.Printf("%d: %d (expected) %d (actual)\n", test.n, test.blocks, x)
+			t.Error("FAIL")
+		}
+	}
+
+	for n := 0; n < 256; n++ {
+		if PaddedBlocks(n) != len(pad512(make([]byte, n)))/64 {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestSum256dVerbose(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+		data := make([]byte, rand.Int()&255)
+		rand.Read(data)
+		first, second := Sum256dVerbose(data)
+		if first != Sha2_256(data) || second != Sha2_256(first[:]) || second != Sha2_256d(data) {
+			t.Error("FAIL")
+		}
+	}
+
+}
@@ -0,0 +1,26 @@
+package sha2
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"math/rand"
+	"testing"
+)
+
+func TestSha2_512(t *testing.T) {
+
+	for i := 0; i < 10000; i++ {
+
+		n := rand.Int() & ((1 << 12) - 1)
+		data := make([]byte, n)
+		rand.Read(data)
+
+		x := Sha2_512(data)
+		y := sha512.Sum512(data)
+
+		if !bytes.Equal(x[:], y[:]) {
+			t.Error("FAIL")
+		}
+	}
+
+}
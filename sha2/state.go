@@ -0,0 +1,127 @@
+//-----------------------------------------------------------------------------
+/*
+
+SHA2-256 Incremental State
+
+Buffers partial 512-bit blocks so data can be hashed in pieces.
+
+*/
+//-----------------------------------------------------------------------------
+
+package sha2
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+)
+
+//-----------------------------------------------------------------------------
+
+const BlockSize = 64
+
+// State is the running state of an incremental SHA256 hash.
+// It can be copied by value, e.g. to reuse a midstate.
+type State struct {
+	h   Hash256         // hash state
+	x   [BlockSize]byte // residual partial block
+	nx  int             // bytes in the residual block
+	len uint64          // total bytes written
+}
+
+// NewState returns an initialized SHA256 state.
+func NewState() *State {
+	s := new(State)
+	s.Reset()
+	return s
+}
+
+// Reset sets the state to the initial SHA256 state.
+func (s *State) Reset() {
+	s.h = hInit
+	s.nx = 0
+	s.len = 0
+}
+
+// Write adds data to the hash. Full 512-bit blocks are hashed and any
+// partial block is buffered.
+func (s *State) Write(p []byte) {
+	s.len += uint64(len(p))
+	// fill the residual block
+	if s.nx > 0 {
+		k := copy(s.x[s.nx:], p)
+		s.nx += k
+		if s.nx == BlockSize {
+			s.h.Add512(s.x[:])
+			s.nx = 0
+		}
+		p = p[k:]
+	}
+	// hash whole blocks directly from the input
+	for len(p) >= BlockSize {
+		s.h.Add512(p[:BlockSize])
+		p = p[BlockSize:]
+	}
+	// keep the remainder
+	if len(p) > 0 {
+		s.nx = copy(s.x[:], p)
+	}
+}
+
+// Sum returns the hash of the data written so far.
+// The running state is not changed.
+func (s *State) Sum() [Size256]byte {
+	s0 := *s
+	n := s0.len
+	var tmp [BlockSize + 8]byte
+	tmp[0] = 0x80
+	var t uint64
+	if n%BlockSize < 56 {
+		t = 56 - n%BlockSize
+	} else {
+		t = BlockSize + 56 - n%BlockSize
+	}
+	binary.BigEndian.PutUint64(tmp[t:], n*8)
+	s0.Write(tmp[:t+8])
+	if s0.nx != 0 {
+		panic("s0.nx != 0")
+	}
+	return s0.h.Bytes()
+}
+
+//-----------------------------------------------------------------------------
+
+// Sum256Concat returns the SHA256 hash of the concatenated parts.
+// The parts are hashed in place, so no joined slice is allocated.
+func Sum256Concat(parts ...[]byte) [Size256]byte {
+	var s State
+	s.Reset()
+	for _, p := range parts {
+		s.Write(p)
+	}
+	return s.Sum()
+}
+
+// Sum256Ctx returns the SHA256 hash of the data read from r.
+// The stream is read a block at a time, and if the context is cancelled
+// before the end of the stream it returns ctx.Err().
+func Sum256Ctx(ctx context.Context, r io.Reader) ([Size256]byte, error) {
+	s := NewState()
+	var buf [BlockSize]byte
+	for {
+		if err := ctx.Err(); err != nil {
+			return [Size256]byte{}, err
+		}
+		n, err := io.ReadFull(r, buf[:])
+		s.Write(buf[:n])
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return [Size256]byte{}, err
+		}
+	}
+	return s.Sum(), nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,127 @@
+package sha2
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestState(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+
+		data := make([]byte, rand.Int()&1023)
+		rand.Read(data)
+		y := sha256.Sum256(data)
+
+		// write in pieces split at random boundaries
+		s := NewState()
+		p := data
+		for len(p) > 0 {
+			k := rand.Intn(len(p) + 1)
+			s.Write(p[:k])
+			p = p[k:]
+		}
+
+		if s.Sum() != y {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestStateMidstate(t *testing.T) {
+
+	hdr := make([]byte, 80)
+	rand.Read(hdr)
+
+	// constant prefix written once
+	mid := NewState()
+	mid.Write(hdr[:64])
+
+	for nonce := 0; nonce < 16; nonce++ {
+		hdr[76] = byte(nonce)
+		s := *mid
+		s.Write(hdr[64:])
+		if s.Sum() != sha256.Sum256(hdr) {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+// slowReader delays each read.
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(s.delay)
+	return s.r.Read(p)
+}
+
+func TestSum256Ctx(t *testing.T) {
+
+	for i := 0; i < 100; i++ {
+		data := make([]byte, rand.Int()&1023)
+		rand.Read(data)
+		x, err := Sum256Ctx(context.Background(), bytes.NewReader(data))
+		if err != nil || x != sha256.Sum256(data) {
+			t.Error("FAIL")
+		}
+	}
+
+	// cancel part way through a slow stream
+	data := make([]byte, 1<<20)
+	r := &slowReader{r: bytes.NewReader(data), delay: time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := Sum256Ctx(ctx, r)
+	if err != context.DeadlineExceeded {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestSum256Concat(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+		a := make([]byte, rand.Int()&127)
+		b := make([]byte, rand.Int()&127)
+		rand.Read(a)
+		rand.Read(b)
+		x := Sum256Concat(a, b)
+		y := Sha2_256(append(append([]byte{}, a...), b...))
+		if x != y {
+			t.Error("FAIL")
+		}
+	}
+
+	if Sum256Concat() != sha256.Sum256(nil) {
+		t.Error("FAIL")
+	}
+
+}
+
+func BenchmarkSum256Concat(b *testing.B) {
+	x := make([]byte, Size256)
+	y := make([]byte, Size256)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Sum256Concat(x, y)
+	}
+}
+
+func BenchmarkSum256Append(b *testing.B) {
+	x := make([]byte, Size256)
+	y := make([]byte, Size256)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Sha2_256(append(append([]byte{}, x...), y...))
+	}
+}
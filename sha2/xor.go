@@ -0,0 +1,34 @@
+//-----------------------------------------------------------------------------
+/*
+
+XOR Hash Accumulator
+
+An order independent commitment to a set of hashes.
+
+*/
+//-----------------------------------------------------------------------------
+
+package sha2
+
+//-----------------------------------------------------------------------------
+
+// XorAccumulator is the word-wise XOR of a set of hashes.
+// The zero value is an empty set. The result doesn't depend on the order
+// the hashes are added in, and adding a hash twice removes it.
+type XorAccumulator struct {
+	x Hash256
+}
+
+// Add adds a hash to the accumulator.
+func (a *XorAccumulator) Add(h Hash256) {
+	for i := range a.x {
+		a.x[i] ^= h[i]
+	}
+}
+
+// Result returns the XOR of the hashes added so far.
+func (a *XorAccumulator) Result() Hash256 {
+	return a.x
+}
+
+//-----------------------------------------------------------------------------
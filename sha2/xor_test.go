@@ -0,0 +1,60 @@
+package sha2
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randHash() Hash256 {
+	var h Hash256
+	for i := range h {
+		h[i] = rand.Uint32()
+	}
+	return h
+}
+
+func TestXorAccumulator(t *testing.T) {
+
+	hashes := make([]Hash256, 20)
+	for i := range hashes {
+		hashes[i] = randHash()
+	}
+
+	var a XorAccumulator
+	for _, h := range hashes {
+		a.Add(h)
+	}
+
+	// the same set in a different order
+	var b XorAccumulator
+	for _, i := range rand.Perm(len(hashes)) {
+		b.Add(hashes[i])
+	}
+	x, y := a.Result(), b.Result()
+	if !x.Equal(&y) {
+		t.Error("FAIL")
+	}
+
+	// adding a hash twice cancels it out
+	h := randHash()
+	b.Add(h)
+	y = b.Result()
+	if x.Equal(&y) {
+		t.Error("FAIL")
+	}
+	b.Add(h)
+	y = b.Result()
+	if !x.Equal(&y) {
+		t.Error("FAIL")
+	}
+
+	// the empty set
+	var c XorAccumulator
+	c.Add(h)
+	c.Add(h)
+	z := c.Result()
+	if !z.IsZero() {
+		t.Error("FAIL")
+	}
+
+}
@@ -0,0 +1,151 @@
+//-----------------------------------------------------------------------------
+/*
+
+CompactSize Unsigned Integers
+
+https://developer.bitcoin.org/reference/transactions.html#compactsize-unsigned-integers
+
+*/
+//-----------------------------------------------------------------------------
+
+package util
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+//-----------------------------------------------------------------------------
+
+// CompactSizeLen returns the encoded length of a CompactSize value.
+func CompactSizeLen(v uint64) int {
+	switch {
+	case v < 0xfd:
+		return 1
+	case v <= 0xffff:
+		return 3
+	case v <= 0xffffffff:
+		return 5
+	}
+	return 9
+}
+
+// PutCompactSize encodes a CompactSize value and returns the bytes written.
+func PutCompactSize(buf []byte, v uint64) int {
+	n := CompactSizeLen(v)
+	if len(buf) < n {
+		panic("len(buf) < CompactSizeLen(v)")
+	}
+	switch n {
+	case 1:
+		buf[0] = uint8(v)
+	case 3:
+		buf[0] = 0xfd
+		binary.LittleEndian.PutUint16(buf[1:], uint16(v))
+	case 5:
+		buf[0] = 0xfe
+		binary.LittleEndian.PutUint32(buf[1:], uint32(v))
+	default:
+		buf[0] = 0xff
+		binary.LittleEndian.PutUint64(buf[1:], v)
+	}
+	return n
+}
+
+// CompactSize decodes a CompactSize value and returns the bytes read.
+func CompactSize(buf []byte) (uint64, int, error) {
+	if len(buf) == 0 {
+		return 0, 0, errors.New("no input")
+	}
+	var n int
+	switch buf[0] {
+	case 0xfd:
+		n = 3
+	case 0xfe:
+		n = 5
+	case 0xff:
+		n = 9
+	default:
+		return uint64(buf[0]), 1, nil
+	}
+	if len(buf) < n {
+		return 0, 0, errors.New("compact size is truncated")
+	}
+	var v uint64
+	switch n {
+	case 3:
+		v = uint64(binary.LittleEndian.Uint16(buf[1:]))
+	case 5:
+		v = uint64(binary.LittleEndian.Uint32(buf[1:]))
+	default:
+		v = binary.LittleEndian.Uint64(buf[1:])
+	}
+	return v, n, nil
+}
+
+// ReadCompactSize reads a CompactSize value from r.
+// It returns io.EOF if no bytes were read, and io.ErrUnexpectedEOF if the
+// value is truncated.
+func ReadCompactSize(r io.Reader) (uint64, error) {
+	var buf [9]byte
+	_, err := io.ReadFull(r, buf[:1])
+	if err != nil {
+		return 0, err
+	}
+	n := 1
+	switch buf[0] {
+	case 0xfd:
+		n = 3
+	case 0xfe:
+		n = 5
+	case 0xff:
+		n = 9
+	}
+	if n > 1 {
+		_, err = io.ReadFull(r, buf[1:n])
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	v, _, err := CompactSize(buf[:n])
+	return v, err
+}
+
+// WriteCompactSize writes a CompactSize value to w.
+func WriteCompactSize(w io.Writer, v uint64) error {
+	var buf [9]byte
+	n := PutCompactSize(buf[:], v)
+	_, err := w.Write(buf[:n])
+	return err
+}
+
+//-----------------------------------------------------------------------------
+
+// PutVarBytes encodes a CompactSize length and the data, returning the bytes written.
+func PutVarBytes(buf []byte, data []byte) int {
+	n := PutCompactSize(buf, uint64(len(data)))
+	if len(buf) < n+len(data) {
+		panic("len(buf) < n+len(data)")
+	}
+	return n + copy(buf[n:], data)
+}
+
+// VarBytes decodes CompactSize length prefixed data, returning the bytes read.
+// The returned data is a sub-slice of buf.
+func VarBytes(buf []byte) ([]byte, int, error) {
+	length, n, err := CompactSize(buf)
+	if err != nil {
+		return nil, 0, err
+	}
+	if length > uint64(len(buf)-n) {
+		return nil, 0, errors.New("length exceeds buffer")
+	}
+	end := n + int(length)
+	return buf[n:end], end, nil
+}
+
+//-----------------------------------------------------------------------------
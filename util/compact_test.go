@@ -0,0 +1,133 @@
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+var compactTests = []struct {
+	v   uint64
+	out string
+}{
+	{0, "00"},
+	{0xfc, "fc"},
+	{0xfd, "fdfd00"},
+	{0xffff, "fdffff"},
+	{0x10000, "fe00000100"},
+	{0xffffffff, "feffffffff"},
+	{0x100000000, "ff0000000001000000"},
+	{0xffffffffffffffff, "ffffffffffffffffff"},
+}
+
+func TestCompactSize(t *testing.T) {
+
+	for _, test := range compactTests {
+
+		var buf [9]byte
+		n := PutCompactSize(buf[:], test.v)
+		x := hex.EncodeToString(buf[:n])
+		if x != test.out {
+			fmt.Printf("%s (expected) %s (actual)\n", test.out, x)
+			t.Error("FAIL")
+		}
+
+		v, m, err := CompactSize(buf[:n])
+		if err != nil || v != test.v || m != n {
+			fmt.Printf("%d %d (expected) %d %d (actual)\n", test.v, n, v, m)
+			t.Error("FAIL")
+		}
+
+		// truncated input
+		if n > 1 {
+			_, _, err = CompactSize(buf[:n-1])
+			if err == nil {
+				t.Error("FAIL")
+			}
+		}
+	}
+
+}
+
+func TestReadCompactSize(t *testing.T) {
+
+	for _, test := range compactTests {
+
+		var buf bytes.Buffer
+		err := WriteCompactSize(&buf, test.v)
+		x := hex.EncodeToString(buf.Bytes())
+		if err != nil || x != test.out {
+			fmt.Printf("%s (expected) %s (actual)\n", test.out, x)
+			t.Error("FAIL")
+		}
+
+		data := buf.Bytes()
+		r := bytes.NewReader(data)
+		v, err := ReadCompactSize(r)
+		if err != nil || v != test.v || r.Len() != 0 {
+			fmt.Printf("%d (expected) %d (actual)\n", test.v, v)
+			t.Error("FAIL")
+		}
+
+		// truncated input
+		if len(data) > 1 {
+			_, err = ReadCompactSize(bytes.NewReader(data[:len(data)-1]))
+			if err != io.ErrUnexpectedEOF {
+				t.Error("FAIL")
+			}
+		}
+	}
+
+	// no input
+	_, err := ReadCompactSize(bytes.NewReader(nil))
+	if err != io.EOF {
+		t.Error("FAIL")
+	}
+
+	// consecutive values
+	r := bytes.NewReader([]byte{0x01, 0xfd, 0x00, 0x01, 0x02})
+	for _, expected := range []uint64{1, 0x100, 2} {
+		v, err := ReadCompactSize(r)
+		if err != nil || v != expected {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestVarBytes(t *testing.T) {
+
+	for _, k := range []int{0, 1, 0xfc, 300} {
+
+		data := make([]byte, k)
+		rand.Read(data)
+
+		buf := make([]byte, CompactSizeLen(uint64(k))+k)
+		n := PutVarBytes(buf, data)
+		if n != len(buf) {
+			t.Error("FAIL")
+		}
+
+		x, m, err := VarBytes(buf)
+		if err != nil || m != n || !bytes.Equal(x, data) {
+			t.Error("FAIL")
+		}
+
+		// truncated buffer
+		if k > 0 {
+			_, _, err = VarBytes(buf[:len(buf)-1])
+			if err == nil {
+				t.Error("FAIL")
+			}
+		}
+	}
+
+	// a 300 byte script needs a 3 byte length
+	if CompactSizeLen(300) != 3 {
+		t.Error("FAIL")
+	}
+
+}
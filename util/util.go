@@ -2,23 +2,139 @@ package util
 
 import (
 	"fmt"
+	"io"
+	"math/bits"
 	"strings"
 )
 
 func Dump8(x []byte) string {
-	s := make([]string, len(x))
+	var sb strings.Builder
+	Fdump8(&sb, x)
+	return sb.String()
+}
+
+// Fdump8 writes the Dump8 form of a byte slice to w.
+func Fdump8(w io.Writer, x []byte) {
 	for i := 0; i < len(x); i++ {
-		s[i] = fmt.Sprintf("%02x ", x[i])
+		fmt.Fprintf(w, "%02x ", x[i])
+	}
+	fmt.Fprintf(w, "(%d)", len(x))
+}
+
+// Dump8Width returns a hexdump of a byte slice with width bytes per line.
+// Each line starts with the offset. A width <= 0 puts all bytes on one line.
+func Dump8Width(x []byte, width int) string {
+	if width <= 0 {
+		width = len(x)
+	}
+	var sb strings.Builder
+	for i := 0; i < len(x); i += width {
+		end := i + width
+		if end > len(x) {
+			end = len(x)
+		}
+		sb.WriteString(fmt.Sprintf("%04x ", i))
+		for _, c := range x[i:end] {
+			sb.WriteString(fmt.Sprintf(" %02x", c))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Dump8Ascii returns a hexdump of a byte slice with an ASCII gutter.
+func Dump8Ascii(x []byte) string {
+	var sb strings.Builder
+	for i := 0; i < len(x); i += 16 {
+		end := i + 16
+		if end > len(x) {
+			end = len(x)
+		}
+		line := x[i:end]
+		sb.WriteString(fmt.Sprintf("%04x  ", i))
+		for j := 0; j < 16; j++ {
+			if j < len(line) {
+				sb.WriteString(fmt.Sprintf("%02x ", line[j]))
+			} else {
+				sb.WriteString("   ")
+			}
+		}
+		sb.WriteString(" |")
+		for _, c := range line {
+			if c >= 0x20 && c < 0x7f {
+				sb.WriteByte(c)
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteString("|\n")
+	}
+	return sb.String()
+}
+
+// DumpDiff returns a hexdump of two byte slices, one line of each per 16 bytes.
+// Bytes that differ (or are past the end of the shorter slice) are marked
+// with "^^" on the following line.
+func DumpDiff(a, b []byte) string {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	hexByte := func(x []byte, i int) string {
+		if i < len(x) {
+			return fmt.Sprintf(" %02x", x[i])
+		}
+		return " --"
 	}
-	return strings.Join(s, "") + fmt.Sprintf("(%d)", len(x))
+	var sb strings.Builder
+	for i := 0; i < n; i += 16 {
+		end := i + 16
+		if end > n {
+			end = n
+		}
+		var la, lb, mark strings.Builder
+		diff := false
+		for j := i; j < end; j++ {
+			la.WriteString(hexByte(a, j))
+			lb.WriteString(hexByte(b, j))
+			if j >= len(a) || j >= len(b) || a[j] != b[j] {
+				mark.WriteString(" ^^")
+				diff = true
+			} else {
+				mark.WriteString("   ")
+			}
+		}
+		sb.WriteString(fmt.Sprintf("%04x a%s\n", i, la.String()))
+		sb.WriteString(fmt.Sprintf("     b%s\n", lb.String()))
+		if diff {
+			sb.WriteString(strings.TrimRight("      "+mark.String(), " ") + "\n")
+		}
+	}
+	return sb.String()
 }
 
 func Dump32(x []uint32) string {
-	s := make([]string, len(x))
+	var sb strings.Builder
+	Fdump32(&sb, x)
+	return sb.String()
+}
+
+// Dump32LE returns the Dump32 form of a uint32 slice with each word
+// byte-reversed, matching how little-endian words appear on the wire.
+func Dump32LE(x []uint32) string {
+	y := make([]uint32, len(x))
+	for i := range x {
+		y[i] = bits.ReverseBytes32(x[i])
+	}
+	return Dump32(y)
+}
+
+// Fdump32 writes the Dump32 form of a uint32 slice to w.
+func Fdump32(w io.Writer, x []uint32) {
 	for i := 0; i < len(x); i++ {
-		s[i] = fmt.Sprintf("%08x ", x[i])
+		fmt.Fprintf(w, "%08x ", x[i])
 	}
-	return strings.Join(s, "") + fmt.Sprintf("(%d)", len(x))
+	fmt.Fprintf(w, "(%d)", len(x))
 }
 
 // Conv32to82 converts a slice of uint32 to a slice of byte
@@ -46,3 +162,45 @@ func Conv8to32(dst []uint32, src []byte) {
 			(uint32(src[i*4+3]) << 0)
 	}
 }
+
+// Conv32to8LE converts a slice of uint32 to a slice of little-endian bytes
+func Conv32to8LE(dst []byte, src []uint32) {
+	if len(dst) != 4*len(src) {
+		panic("len(dst) != 4 * len(src)")
+	}
+	for i := 0; i < len(src); i++ {
+		dst[i*4+0] = uint8(src[i] >> 0)
+		dst[i*4+1] = uint8(src[i] >> 8)
+		dst[i*4+2] = uint8(src[i] >> 16)
+		dst[i*4+3] = uint8(src[i] >> 24)
+	}
+}
+
+// Conv8to32LE converts a slice of little-endian bytes to a slice of uint32
+func Conv8to32LE(dst []uint32, src []byte) {
+	if len(src) != 4*len(dst) {
+		panic("len(src) != 4*len(dst)")
+	}
+	for i := 0; i < len(dst); i++ {
+		dst[i] = (uint32(src[i*4+0]) << 0) |
+			(uint32(src[i*4+1]) << 8) |
+			(uint32(src[i*4+2]) << 16) |
+			(uint32(src[i*4+3]) << 24)
+	}
+}
+
+// Reverse returns a reversed copy of a byte slice
+func Reverse(x []byte) []byte {
+	y := make([]byte, len(x))
+	for i := 0; i < len(x); i++ {
+		y[len(x)-1-i] = x[i]
+	}
+	return y
+}
+
+// ReverseInPlace reverses a byte slice
+func ReverseInPlace(x []byte) {
+	for i, j := 0, len(x)-1; i < j; i, j = i+1, j-1 {
+		x[i], x[j] = x[j], x[i]
+	}
+}
@@ -0,0 +1,184 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestConvLE(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+
+		n := rand.Int() & 31
+		src := make([]uint32, n)
+		for j := range src {
+			src[j] = rand.Uint32()
+		}
+
+		x := make([]byte, 4*n)
+		Conv32to8LE(x, src)
+
+		y := make([]byte, 4*n)
+		for j := range src {
+			binary.LittleEndian.PutUint32(y[4*j:], src[j])
+		}
+		if !bytes.Equal(x, y) {
+			t.Error("FAIL")
+		}
+
+		z := make([]uint32, n)
+		Conv8to32LE(z, x)
+		for j := range src {
+			if z[j] != src[j] {
+				t.Error("FAIL")
+			}
+		}
+	}
+
+}
+
+func TestReverse(t *testing.T) {
+
+	x := make([]byte, 32)
+	rand.Read(x)
+
+	y := Reverse(x)
+	for i := range x {
+		if y[i] != x[len(x)-1-i] {
+			t.Error("FAIL")
+		}
+	}
+
+	z := Reverse(y)
+	if !bytes.Equal(x, z) {
+		t.Error("FAIL")
+	}
+
+	ReverseInPlace(y)
+	if !bytes.Equal(x, y) {
+		t.Error("FAIL")
+	}
+
+}
+
+// block 125552 header
+const hdr125552 = "0100000081cd02ab7e569e8bcd9317e2fe99f2de44d49ab2b8851ba4a308000000000000e320b6c2fffc8d750423db8b1eb942ae710e951ed797f7affc8892b0f1fc122bc7f5d74df2b9441a42a14695"
+
+func TestDump8Ascii(t *testing.T) {
+
+	x, _ := hex.DecodeString(hdr125552)
+	golden, err := os.ReadFile("testdata/dump8ascii.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := Dump8Ascii(x)
+	if s != string(golden) {
+		fmt.Printf("%s(expected)\n%s(actual)\n", golden, s)
+		t.Error("FAIL")
+	}
+
+}
+
+func TestFdump(t *testing.T) {
+
+	x := []byte{0x01, 0xab, 0x00}
+	var buf bytes.Buffer
+	Fdump8(&buf, x)
+	if buf.String() != Dump8(x) || buf.String() != "01 ab 00 (3)" {
+		fmt.Printf("%s (actual)\n", buf.String())
+		t.Error("FAIL")
+	}
+
+	y := []uint32{0x12345678, 0xabc}
+	buf.Reset()
+	Fdump32(&buf, y)
+	if buf.String() != Dump32(y) || buf.String() != "12345678 00000abc (2)" {
+		fmt.Printf("%s (actual)\n", buf.String())
+		t.Error("FAIL")
+	}
+
+}
+
+func TestDump8Width(t *testing.T) {
+
+	x, _ := hex.DecodeString(hdr125552)
+	golden, err := os.ReadFile("testdata/dump8width16.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := Dump8Width(x, 16)
+	if s != string(golden) {
+		fmt.Printf("%s(expected)\n%s(actual)\n", golden, s)
+		t.Error("FAIL")
+	}
+
+	if Dump8Width(x[:3], 0) != "0000  01 00 00\n" {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestDump32LE(t *testing.T) {
+
+	// version 1, bits 0x1a44b9f2
+	x := []uint32{1, 0x1a44b9f2}
+	s := Dump32LE(x)
+	if s != "01000000 f2b9441a (2)" {
+		fmt.Printf("%s (actual)\n", s)
+		t.Error("FAIL")
+	}
+
+	// same as the wire bytes
+	y := make([]byte, 8)
+	Conv32to8LE(y, x)
+	z := make([]uint32, 2)
+	Conv8to32(z, y)
+	if s != Dump32(z) {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestDumpDiff(t *testing.T) {
+
+	a, _ := hex.DecodeString(hdr125552)
+	b := append([]byte{}, a...)
+	b[18] ^= 0x80
+
+	s := DumpDiff(a, b)
+	lines := strings.Split(s, "\n")
+	// 5 lines of 16 bytes, a caret line for the second, trailing newline
+	if len(lines) != 5*2+1+1 {
+		fmt.Printf("%s(actual)\n", s)
+		t.Error("FAIL")
+		return
+	}
+	if lines[4] != strings.Repeat(" ", 6+3*2)+" ^^" {
+		fmt.Printf("%q (actual)\n", lines[4])
+		t.Error("FAIL")
+	}
+	if strings.Count(s, "^^") != 1 {
+		t.Error("FAIL")
+	}
+
+	// equal slices have no marks
+	if strings.Contains(DumpDiff(a, a), "^") {
+		t.Error("FAIL")
+	}
+
+	// different lengths
+	s = DumpDiff([]byte{1, 2}, []byte{1})
+	if s != "0000 a 01 02\n     b 01 --\n          ^^\n" {
+		fmt.Printf("%q (actual)\n", s)
+		t.Error("FAIL")
+	}
+
+}
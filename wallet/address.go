@@ -0,0 +1,42 @@
+//-----------------------------------------------------------------------------
+/*
+
+Bitcoin Addresses
+
+https://en.bitcoin.it/wiki/Invoice_address
+
+*/
+//-----------------------------------------------------------------------------
+
+package wallet
+
+import (
+	"github.com/deadsy/bcx/base58"
+	"github.com/deadsy/bcx/block"
+	"github.com/deadsy/bcx/ripemd160"
+)
+
+//-----------------------------------------------------------------------------
+
+// network returns the mainnet or testnet network for the bool API.
+func network(mainnet bool) *block.Network {
+	if mainnet {
+		return block.Mainnet
+	}
+	return block.Testnet
+}
+
+// AddressP2PKHNet returns the pay-to-pubkey-hash address for a Hash160.
+// Mainnet addresses start with "1", testnet and regtest addresses with "m" or "n".
+func AddressP2PKHNet(hash160 [ripemd160.Size]byte, net *block.Network) string {
+	return base58.CheckEncode(net.P2PKHVersion(), hash160[:])
+}
+
+// AddressP2PKH returns the mainnet or testnet pay-to-pubkey-hash address for a Hash160.
+//
+// Deprecated: Use AddressP2PKHNet.
+func AddressP2PKH(hash160 [ripemd160.Size]byte, mainnet bool) string {
+	return AddressP2PKHNet(hash160, network(mainnet))
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/deadsy/bcx/block"
+	"github.com/deadsy/bcx/ripemd160"
+)
+
+// genesis coinbase public key hash
+const genesisHash160 = "62e907b15cbf27d5425399ebf6f0fb50ebb88f18"
+
+func hash160FromString(s string) [ripemd160.Size]byte {
+	var out [ripemd160.Size]byte
+	x, _ := hex.DecodeString(s)
+	copy(out[:], x)
+	return out
+}
+
+func TestAddressP2PKH(t *testing.T) {
+
+	h := hash160FromString(genesisHash160)
+
+	x := AddressP2PKH(h, true)
+	if x != "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa" {
+		fmt.Printf("%s (actual)\n", x)
+		t.Error("FAIL")
+	}
+
+	x = AddressP2PKH(h, false)
+	if x != "mpXwg4jMtRhuSpVq4xS3HFHmCmWp9NyGKt" {
+		fmt.Printf("%s (actual)\n", x)
+		t.Error("FAIL")
+	}
+
+}
+
+func TestAddressNetwork(t *testing.T) {
+
+	h := hash160FromString("751e76e8199196d454941c45d1b3a323f1433bd6")
+
+	// regtest P2PKH uses the testnet version
+	x := AddressP2PKHNet(h, block.Regtest)
+	if x != AddressP2PKH(h, false) {
+		t.Error("FAIL")
+	}
+	if AddressP2PKHNet(h, block.Mainnet) != AddressP2PKH(h, true) {
+		t.Error("FAIL")
+	}
+
+	// regtest bech32
+	x, err := AddressP2WPKHNet(h, block.Regtest)
+	expected := "bcrt1qw508d6qejxtdg4y5r3zarvary0c5xw7kygt080"
+	if err != nil || x != expected {
+		fmt.Printf("%s (expected) %s (actual)\n", expected, x)
+		t.Error("FAIL")
+	}
+	kind, payload, err := AddressDecode(x)
+	if err != nil || kind != "p2wpkh" || !bytes.Equal(payload, h[:]) {
+		t.Error("FAIL")
+	}
+
+	// WIF
+	var key [KeySize]byte
+	copy(key[:], h[:])
+	for _, net := range []*block.Network{block.Mainnet, block.Testnet, block.Regtest} {
+		k, c, n, err := WIFDecodeNet(WIFEncodeNet(key, true, net))
+		if err != nil || k != key || !c || n.WIFVersion() != net.WIFVersion() {
+			t.Error("FAIL")
+		}
+	}
+
+}
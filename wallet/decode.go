@@ -0,0 +1,145 @@
+//-----------------------------------------------------------------------------
+/*
+
+Address Decoding
+
+*/
+//-----------------------------------------------------------------------------
+
+package wallet
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/deadsy/bcx/base58"
+	"github.com/deadsy/bcx/bech32"
+	"github.com/deadsy/bcx/block"
+	"github.com/deadsy/bcx/ripemd160"
+)
+
+//-----------------------------------------------------------------------------
+
+// base58 address kinds by version byte (regtest uses the testnet versions)
+var addressKinds = map[byte]string{
+	block.Mainnet.P2PKHVersion(): "p2pkh-mainnet",
+	block.Mainnet.P2SHVersion():  "p2sh-mainnet",
+	block.Testnet.P2PKHVersion(): "p2pkh-testnet",
+	block.Testnet.P2SHVersion():  "p2sh-testnet",
+}
+
+// segwitNetworks are the networks with bech32 addresses.
+var segwitNetworks = []*block.Network{block.Mainnet, block.Testnet, block.Regtest}
+
+// isSegwit returns true if the address starts with a known bech32 human readable part.
+func isSegwit(s string) bool {
+	s = strings.ToLower(s)
+	for _, net := range segwitNetworks {
+		if strings.HasPrefix(s, net.Bech32HRP()+"1") {
+			return true
+		}
+	}
+	return false
+}
+
+// segwitNetwork returns the network for a bech32 human readable part.
+func segwitNetwork(hrp string) *block.Network {
+	for _, net := range segwitNetworks {
+		if hrp == net.Bech32HRP() {
+			return net
+		}
+	}
+	return nil
+}
+
+// segwitDecode returns the witness version and program of a bech32 address.
+func segwitDecode(s string) (byte, []byte, error) {
+	hrp, data, err := bech32.Decode(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if segwitNetwork(hrp) == nil {
+		return 0, nil, errors.New("unknown bech32 hrp")
+	}
+	if len(data) < 1 {
+		return 0, nil, errors.New("no witness version")
+	}
+	version := data[0]
+	if version > 16 {
+		return 0, nil, errors.New("bad witness version")
+	}
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return 0, nil, errors.New("bad witness program length")
+	}
+	return version, program, nil
+}
+
+// AddressDecode checks an address and returns its kind and payload.
+// Base58 addresses return "p2pkh-mainnet", "p2sh-mainnet", "p2pkh-testnet"
+// or "p2sh-testnet" with the 20 byte hash. Bech32 (version 0 witness)
+// addresses return "p2wpkh" or "p2wsh" with the witness program.
+func AddressDecode(s string) (kind string, payload []byte, err error) {
+	if isSegwit(s) {
+		var version byte
+		version, payload, err = segwitDecode(s)
+		if err != nil {
+			return "", nil, err
+		}
+		switch {
+		case version == 0 && len(payload) == 20:
+			kind = "p2wpkh"
+		case version == 0 && len(payload) == 32:
+			kind = "p2wsh"
+		default:
+			return "", nil, errors.New("unknown witness program")
+		}
+		return kind, payload, nil
+	}
+	version, payload, err := base58.CheckDecode(s)
+	if err != nil {
+		return "", nil, err
+	}
+	kind, ok := addressKinds[version]
+	if !ok {
+		return "", nil, errors.New("unknown address version")
+	}
+	if len(payload) != 20 {
+		return "", nil, errors.New("bad address payload length")
+	}
+	return kind, payload, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// AddressToHash160 returns the Hash160 of a base58 P2PKH or P2SH address.
+// isScript is true for a P2SH (script hash) address.
+func AddressToHash160(s string) (hash [ripemd160.Size]byte, mainnet, isScript bool, err error) {
+	version, payload, err := base58.CheckDecode(s)
+	if err != nil {
+		return
+	}
+	switch version {
+	case block.Mainnet.P2PKHVersion():
+		mainnet = true
+	case block.Testnet.P2PKHVersion():
+	case block.Mainnet.P2SHVersion():
+		mainnet, isScript = true, true
+	case block.Testnet.P2SHVersion():
+		isScript = true
+	default:
+		err = errors.New("unknown address version")
+		return
+	}
+	if len(payload) != ripemd160.Size {
+		err = errors.New("bad address payload length")
+		return
+	}
+	copy(hash[:], payload)
+	return
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,96 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/deadsy/bcx/base58"
+)
+
+func TestAddressDecode(t *testing.T) {
+
+	tests := []struct {
+		addr    string
+		kind    string
+		payload string
+	}{
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "p2pkh-mainnet", genesisHash160},
+		{"mpXwg4jMtRhuSpVq4xS3HFHmCmWp9NyGKt", "p2pkh-testnet", genesisHash160},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "p2sh-mainnet", "b472a266d0bd89c13706a4132ccfb16f7c3b9fcb"},
+		{"2N9hLwkSqr1cPQAPxbrGVUjxyjD11G2e1he", "p2sh-testnet", "b472a266d0bd89c13706a4132ccfb16f7c3b9fcb"},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "p2wpkh", "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "p2wpkh", "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", "p2wsh", "1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+	}
+
+	for _, test := range tests {
+		kind, payload, err := AddressDecode(test.addr)
+		if err != nil || kind != test.kind || hex.EncodeToString(payload) != test.payload {
+			fmt.Printf("%s %s (expected) %s %x %v (actual)\n", test.kind, test.payload, kind, payload, err)
+			t.Error("FAIL")
+		}
+	}
+
+	bad := []string{
+		"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb",                  // bad checksum
+		"5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ", // WIF, not an address
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5",          // bad checksum
+		"bc1zw508d6qejxtdg4y5r3zarvaryvqyzf3du",               // version 2 witness
+		"",
+	}
+
+	for _, s := range bad {
+		_, _, err := AddressDecode(s)
+		if err == nil {
+			fmt.Printf("%s (actual)\n", s)
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestAddressToHash160(t *testing.T) {
+
+	tests := []struct {
+		addr     string
+		hash     string
+		mainnet  bool
+		isScript bool
+	}{
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", genesisHash160, true, false},
+		{"mpXwg4jMtRhuSpVq4xS3HFHmCmWp9NyGKt", genesisHash160, false, false},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "b472a266d0bd89c13706a4132ccfb16f7c3b9fcb", true, true},
+		{"2N9hLwkSqr1cPQAPxbrGVUjxyjD11G2e1he", "b472a266d0bd89c13706a4132ccfb16f7c3b9fcb", false, true},
+	}
+
+	for _, test := range tests {
+		hash, mainnet, isScript, err := AddressToHash160(test.addr)
+		if err != nil || hash != hash160FromString(test.hash) || mainnet != test.mainnet || isScript != test.isScript {
+			fmt.Printf("%s (expected) %x (actual)\n", test.hash, hash)
+			t.Error("FAIL")
+		}
+	}
+
+	// round trip
+	h := hash160FromString(genesisHash160)
+	hash, mainnet, _, err := AddressToHash160(AddressP2PKH(h, true))
+	if err != nil || hash != h || !mainnet {
+		t.Error("FAIL")
+	}
+
+	bad := []string{
+		"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb",                  // bad checksum
+		"5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ", // WIF version
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",          // bech32
+		base58.CheckEncode(0x00, make([]byte, 21)),            // long payload
+	}
+	for _, s := range bad {
+		_, _, _, err := AddressToHash160(s)
+		if err == nil {
+			fmt.Printf("%s (actual)\n", s)
+			t.Error("FAIL")
+		}
+	}
+
+}
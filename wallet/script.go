@@ -0,0 +1,44 @@
+//-----------------------------------------------------------------------------
+/*
+
+Output Scripts (scriptPubKey)
+
+https://en.bitcoin.it/wiki/Script
+
+*/
+//-----------------------------------------------------------------------------
+
+package wallet
+
+import (
+	"errors"
+
+	"github.com/deadsy/bcx/script"
+)
+
+//-----------------------------------------------------------------------------
+
+// AddressToScript returns the output script (scriptPubKey) for an address.
+func AddressToScript(s string) ([]byte, error) {
+	kind, payload, err := AddressDecode(s)
+	if err != nil {
+		return nil, err
+	}
+	n := byte(len(payload))
+	switch kind {
+	case "p2pkh-mainnet", "p2pkh-testnet":
+		// OP_DUP OP_HASH160 <hash160> OP_EQUALVERIFY OP_CHECKSIG
+		pkScript := append([]byte{script.OpDup, script.OpHash160, n}, payload...)
+		return append(pkScript, script.OpEqualVerify, script.OpCheckSig), nil
+	case "p2sh-mainnet", "p2sh-testnet":
+		// OP_HASH160 <hash160> OP_EQUAL
+		pkScript := append([]byte{script.OpHash160, n}, payload...)
+		return append(pkScript, script.OpEqual), nil
+	case "p2wpkh", "p2wsh":
+		// OP_0 <witness program>
+		return append([]byte{script.Op0, n}, payload...), nil
+	}
+	return nil, errors.New("unknown address kind")
+}
+
+//-----------------------------------------------------------------------------
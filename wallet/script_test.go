@@ -0,0 +1,45 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/deadsy/bcx/script"
+)
+
+func TestAddressToScript(t *testing.T) {
+
+	tests := []struct {
+		addr   string
+		script string
+	}{
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac"},
+		{"mpXwg4jMtRhuSpVq4xS3HFHmCmWp9NyGKt", "76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac"},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+	}
+
+	for _, test := range tests {
+		x, err := AddressToScript(test.addr)
+		if err != nil || hex.EncodeToString(x) != test.script {
+			fmt.Printf("%s (expected) %x (actual)\n", test.script, x)
+			t.Error("FAIL")
+		}
+		// the script classifies as the address kind
+		kind, payload, _ := AddressDecode(test.addr)
+		k, data := script.Classify(x)
+		if !strings.HasPrefix(kind, k) || !bytes.Equal(data, payload) {
+			t.Error("FAIL")
+		}
+	}
+
+	_, err := AddressToScript("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb")
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
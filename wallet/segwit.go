@@ -0,0 +1,66 @@
+//-----------------------------------------------------------------------------
+/*
+
+SegWit Addresses
+
+https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
+
+*/
+//-----------------------------------------------------------------------------
+
+package wallet
+
+import (
+	"errors"
+
+	"github.com/deadsy/bcx/bech32"
+	"github.com/deadsy/bcx/block"
+	"github.com/deadsy/bcx/ripemd160"
+	"github.com/deadsy/bcx/sha2"
+)
+
+//-----------------------------------------------------------------------------
+
+// segwitAddress returns the bech32 address for a witness program.
+func segwitAddress(hrp string, version byte, program []byte) (string, error) {
+	if version > 16 {
+		return "", errors.New("bad witness version")
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return "", errors.New("bad witness program length")
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", errors.New("bad witness program length")
+	}
+	data, err := bech32.ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.Encode(hrp, append([]byte{version}, data...))
+}
+
+// AddressP2WPKHNet returns the pay-to-witness-pubkey-hash address for a Hash160.
+func AddressP2WPKHNet(hash160 [ripemd160.Size]byte, net *block.Network) (string, error) {
+	return segwitAddress(net.Bech32HRP(), 0, hash160[:])
+}
+
+// AddressP2WSHNet returns the pay-to-witness-script-hash address for a SHA256 script hash.
+func AddressP2WSHNet(hash [sha2.Size256]byte, net *block.Network) (string, error) {
+	return segwitAddress(net.Bech32HRP(), 0, hash[:])
+}
+
+// AddressP2WPKH returns the mainnet or testnet pay-to-witness-pubkey-hash address for a Hash160.
+//
+// Deprecated: Use AddressP2WPKHNet.
+func AddressP2WPKH(hash160 [ripemd160.Size]byte, mainnet bool) (string, error) {
+	return AddressP2WPKHNet(hash160, network(mainnet))
+}
+
+// AddressP2WSH returns the mainnet or testnet pay-to-witness-script-hash address for a SHA256 script hash.
+//
+// Deprecated: Use AddressP2WSHNet.
+func AddressP2WSH(hash [sha2.Size256]byte, mainnet bool) (string, error) {
+	return AddressP2WSHNet(hash, network(mainnet))
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/deadsy/bcx/sha2"
+)
+
+func TestAddressP2WPKH(t *testing.T) {
+
+	h := hash160FromString("751e76e8199196d454941c45d1b3a323f1433bd6")
+
+	x, err := AddressP2WPKH(h, true)
+	expected := "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+	if err != nil || x != expected {
+		fmt.Printf("%s (expected) %s (actual)\n", expected, x)
+		t.Error("FAIL")
+	}
+
+	x, err = AddressP2WPKH(h, false)
+	expected = "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx"
+	if err != nil || x != expected {
+		fmt.Printf("%s (expected) %s (actual)\n", expected, x)
+		t.Error("FAIL")
+	}
+
+}
+
+func TestAddressP2WSH(t *testing.T) {
+
+	var h [sha2.Size256]byte
+	x, _ := hex.DecodeString("1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262")
+	copy(h[:], x)
+
+	s, err := AddressP2WSH(h, false)
+	expected := "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7"
+	if err != nil || s != expected {
+		fmt.Printf("%s (expected) %s (actual)\n", expected, s)
+		t.Error("FAIL")
+	}
+
+	s, err = AddressP2WSH(h, true)
+	expected = "bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3"
+	if err != nil || s != expected {
+		fmt.Printf("%s (expected) %s (actual)\n", expected, s)
+		t.Error("FAIL")
+	}
+
+}
+
+func TestSegwitProgramLength(t *testing.T) {
+
+	_, err := segwitAddress("bc", 0, make([]byte, 21))
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
@@ -0,0 +1,107 @@
+//-----------------------------------------------------------------------------
+/*
+
+Wallet Import Format
+
+https://en.bitcoin.it/wiki/Wallet_import_format
+
+*/
+//-----------------------------------------------------------------------------
+
+package wallet
+
+import (
+	"errors"
+
+	"github.com/deadsy/bcx/base58"
+	"github.com/deadsy/bcx/block"
+	"github.com/deadsy/bcx/ripemd160"
+)
+
+//-----------------------------------------------------------------------------
+
+const KeySize = 32
+
+// flag for a compressed public key
+const wifCompressed = 0x01
+
+// public key sizes
+const (
+	pubKeySizeCompressed   = 33
+	pubKeySizeUncompressed = 65
+)
+
+// WIFEncodeNet returns the wallet import format string for a private key.
+func WIFEncodeNet(key [KeySize]byte, compressed bool, net *block.Network) string {
+	payload := key[:]
+	if compressed {
+		payload = append(payload, wifCompressed)
+	}
+	return base58.CheckEncode(net.WIFVersion(), payload)
+}
+
+// WIFDecodeNet returns the private key encoded in a wallet import format string.
+// Testnet and regtest use the same version byte, so both decode as Testnet.
+func WIFDecodeNet(s string) (key [KeySize]byte, compressed bool, net *block.Network, err error) {
+	version, payload, err := base58.CheckDecode(s)
+	if err != nil {
+		return
+	}
+	switch version {
+	case block.Mainnet.WIFVersion():
+		net = block.Mainnet
+	case block.Testnet.WIFVersion():
+		net = block.Testnet
+	default:
+		err = errors.New("unknown WIF version")
+		return
+	}
+	switch {
+	case len(payload) == KeySize:
+		compressed = false
+	case len(payload) == KeySize+1 && payload[KeySize] == wifCompressed:
+		compressed = true
+	default:
+		err = errors.New("bad WIF payload")
+		return
+	}
+	copy(key[:], payload)
+	return
+}
+
+// WIFEncode returns the mainnet or testnet wallet import format string for a private key.
+//
+// Deprecated: Use WIFEncodeNet.
+func WIFEncode(key [KeySize]byte, compressed, mainnet bool) string {
+	return WIFEncodeNet(key, compressed, network(mainnet))
+}
+
+// WIFDecode returns the private key encoded in a wallet import format string.
+//
+// Deprecated: Use WIFDecodeNet.
+func WIFDecode(s string) (key [KeySize]byte, compressed, mainnet bool, err error) {
+	var net *block.Network
+	key, compressed, net, err = WIFDecodeNet(s)
+	mainnet = net == block.Mainnet
+	return
+}
+
+// WIFAddress returns the P2PKH address for the public key of a WIF private key.
+// Deriving the public key needs secp256k1 and is not done here, so the caller
+// provides it. The WIF sets the network, and the public key must be in the
+// compressed (33 byte) or uncompressed (65 byte) form given by the WIF.
+func WIFAddress(wif string, pubkey []byte) (string, error) {
+	_, compressed, net, err := WIFDecodeNet(wif)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case compressed && len(pubkey) == pubKeySizeCompressed && (pubkey[0] == 0x02 || pubkey[0] == 0x03):
+	case !compressed && len(pubkey) == pubKeySizeUncompressed && pubkey[0] == 0x04:
+	default:
+		return "", errors.New("public key does not match WIF compression")
+	}
+	return AddressP2PKHNet(ripemd160.Hash160(pubkey), net), nil
+}
+
+//-----------------------------------------------------------------------------
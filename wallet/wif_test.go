@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+var wifTests = []struct {
+	key        string
+	compressed bool
+	mainnet    bool
+	wif        string
+}{
+	{"0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d", false, true, "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"},
+	{"0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d", true, true, "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617"},
+	{"0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d", true, false, "cMzLdeGd5vEqxB8B6VFQoRopQ3sLAAvEzDAoQgvX54xwofSWj1fx"},
+}
+
+func TestWIF(t *testing.T) {
+
+	for _, test := range wifTests {
+
+		var key [KeySize]byte
+		x, _ := hex.DecodeString(test.key)
+		copy(key[:], x)
+
+		s := WIFEncode(key, test.compressed, test.mainnet)
+		if s != test.wif {
+			fmt.Printf("%s (expected) %s (actual)\n", test.wif, s)
+			t.Error("FAIL")
+		}
+
+		k, compressed, mainnet, err := WIFDecode(test.wif)
+		if err != nil || k != key || compressed != test.compressed || mainnet != test.mainnet {
+			t.Error("FAIL")
+		}
+	}
+
+}
+
+func TestWIFRoundTrip(t *testing.T) {
+
+	for i := 0; i < 1000; i++ {
+
+		var key [KeySize]byte
+		rand.Read(key[:])
+		compressed := rand.Int()&1 == 1
+		mainnet := rand.Int()&1 == 1
+
+		k, c, m, err := WIFDecode(WIFEncode(key, compressed, mainnet))
+		if err != nil || k != key || c != compressed || m != mainnet {
+			t.Error("FAIL")
+		}
+	}
+
+	// an address is not a WIF key
+	_, _, _, err := WIFDecode("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa")
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
+
+func TestWIFAddress(t *testing.T) {
+
+	// public keys for 0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d
+	uncompressed := "04d0de0aaeaefad02b8bdc8a01a1b8b11c696bd3d66a2c5f10780d95b7df42645cd85228a6fb29940e858e7e55842ae2bd115d1ed7cc0e82d934e929c97648cb0a"
+	compressed := "02d0de0aaeaefad02b8bdc8a01a1b8b11c696bd3d66a2c5f10780d95b7df42645c"
+
+	tests := []struct {
+		wif    string
+		pubkey string
+		addr   string
+	}{
+		{wifTests[0].wif, uncompressed, "1GAehh7TsJAHuUAeKZcXf5CnwuGuGgyX2S"},
+		{wifTests[1].wif, compressed, "1LoVGDgRs9hTfTNJNuXKSpywcbdvwRXpmK"},
+		{wifTests[2].wif, compressed, "n1KSZGmQgB8iSZqv6UVhGkCGUbEdw8Lm3Q"},
+	}
+
+	for _, test := range tests {
+		pubkey, _ := hex.DecodeString(test.pubkey)
+		addr, err := WIFAddress(test.wif, pubkey)
+		if err != nil || addr != test.addr {
+			fmt.Printf("%s (expected) %s (actual)\n", test.addr, addr)
+			t.Error("FAIL")
+		}
+	}
+
+	// the public key form doesn't match the WIF
+	pubkey, _ := hex.DecodeString(compressed)
+	_, err := WIFAddress(wifTests[0].wif, pubkey)
+	if err == nil {
+		t.Error("FAIL")
+	}
+
+}
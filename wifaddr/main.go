@@ -0,0 +1,63 @@
+/*
+
+Print the P2PKH address for a WIF private key and its public key.
+
+usage: wifaddr <wif> <pubkey hex>
+
+e.g.
+wifaddr 5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ 04d0de0aaeaefad02b8bdc8a01a1b8b11c696bd3d66a2c5f10780d95b7df42645cd85228a6fb29940e858e7e55842ae2bd115d1ed7cc0e82d934e929c97648cb0a
+1GAehh7TsJAHuUAeKZcXf5CnwuGuGgyX2S
+
+*/
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/deadsy/bcx/ripemd160"
+	"github.com/deadsy/bcx/util"
+	"github.com/deadsy/bcx/wallet"
+)
+
+func wifaddr(wif, pubkeyHex string) error {
+
+	_, compressed, net, err := wallet.WIFDecodeNet(wif)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("compressed %v network %s\n", compressed, net)
+
+	pubkey, err := hex.DecodeString(pubkeyHex)
+	if err != nil {
+		return err
+	}
+	hash := ripemd160.Hash160(pubkey)
+	fmt.Printf("hash160 %s\n", util.Dump8(hash[:]))
+
+	addr, err := wallet.WIFAddress(wif, pubkey)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", addr)
+
+	return nil
+}
+
+func main() {
+
+	if len(os.Args) != 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s <wif> <pubkey hex>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	err := wifaddr(os.Args[1], os.Args[2])
+
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+}